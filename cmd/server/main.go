@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,6 +15,7 @@ import (
 	"github.com/quckapp/channel-service/internal/db"
 	"github.com/quckapp/channel-service/internal/repository"
 	"github.com/quckapp/channel-service/internal/service"
+	"github.com/quckapp/channel-service/internal/unfurl"
 	"github.com/sirupsen/logrus"
 )
 
@@ -55,18 +57,22 @@ func main() {
 	}
 
 	// Initialize Redis
-	_, err = db.NewRedis(cfg.RedisURL)
+	redisClient, err := db.NewRedis(cfg.RedisURL)
 	if err != nil {
 		logger.WithError(err).Warn("Failed to connect to Redis, continuing without cache")
+		redisClient = nil
 	} else {
+		defer redisClient.Close()
 		logger.Info("Connected to Redis")
 	}
 
 	// Initialize Kafka producer
+	var kafkaProducer *db.KafkaProducer
 	if len(cfg.KafkaBrokers) > 0 && cfg.KafkaBrokers[0] != "" {
-		kafkaProducer, err := db.NewKafkaProducer(cfg.KafkaBrokers)
+		kafkaProducer, err = db.NewKafkaProducer(cfg.KafkaBrokers)
 		if err != nil {
 			logger.WithError(err).Warn("Failed to connect to Kafka, continuing without events")
+			kafkaProducer = nil
 		} else {
 			defer kafkaProducer.Close()
 			logger.WithField("brokers", cfg.KafkaBrokers).Info("Connected to Kafka")
@@ -74,26 +80,97 @@ func main() {
 	}
 
 	// Initialize repositories
+	channelRepo := repository.NewChannelRepository(mysqlDB)
 	pollRepo := repository.NewPollRepository(mysqlDB)
 	scheduledMessageRepo := repository.NewScheduledMessageRepository(mysqlDB)
 	channelLinkRepo := repository.NewChannelLinkRepository(mysqlDB)
 	tabRepo := repository.NewTabRepository(mysqlDB)
 	followerRepo := repository.NewFollowerRepository(mysqlDB)
 	templateRepo := repository.NewTemplateRepository(mysqlDB)
+	reactionRepo := repository.NewReactionRepository(mysqlDB)
+	settingsRepo := repository.NewSettingsRepository(mysqlDB)
+	emojiRepo := repository.NewEmojiRepository(mysqlDB)
+	memberRepo := repository.NewMemberRepository(mysqlDB)
+	readReceiptRepo := repository.NewReadReceiptRepository(mysqlDB)
+	messageRepo := repository.NewMessageRepository(mysqlDB)
+	analyticsRepo := repository.NewAnalyticsRepository(mysqlDB)
+	activityLogRepo := repository.NewActivityLogRepository(mysqlDB)
+	inviteRepo := repository.NewInviteRepository(mysqlDB)
+	starredRepo := repository.NewStarredRepository(mysqlDB)
+	sectionRepo := repository.NewSectionRepository(mysqlDB)
+	bookmarkRepo := repository.NewBookmarkRepository(mysqlDB)
+	announcementRepo := repository.NewAnnouncementRepository(mysqlDB)
+	topicHistoryRepo := repository.NewTopicHistoryRepository(mysqlDB)
+	moderationRepo := repository.NewModerationRepository(mysqlDB)
+	voiceRepo := repository.NewVoiceRepository(mysqlDB)
+	permissionRepo := repository.NewPermissionRepository(mysqlDB)
+	pinRepo := repository.NewPinRepository(mysqlDB)
 	logger.Info("Repositories initialized")
 
+	unfurler := unfurl.NewUnfurler(unfurl.NewHTTPFetcher(unfurl.Options{
+		Timeout:  cfg.UnfurlTimeout,
+		MaxBytes: cfg.UnfurlMaxBytes,
+	}), redisClient, cfg.UnfurlCacheTTL)
+
 	// Initialize service
 	channelService := service.NewChannelService(
+		channelRepo,
+		memberRepo,
 		pollRepo,
 		scheduledMessageRepo,
 		channelLinkRepo,
 		tabRepo,
 		followerRepo,
 		templateRepo,
+		reactionRepo,
+		settingsRepo,
+		emojiRepo,
+		readReceiptRepo,
+		messageRepo,
+		analyticsRepo,
+		activityLogRepo,
+		inviteRepo,
+		starredRepo,
+		sectionRepo,
+		bookmarkRepo,
+		announcementRepo,
+		topicHistoryRepo,
+		moderationRepo,
+		voiceRepo,
+		permissionRepo,
+		pinRepo,
+		unfurler,
+		redisClient,
+		kafkaProducer,
 		logger,
 	)
 	logger.Info("Service layer initialized")
 
+	// Start background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	if len(cfg.KafkaBrokers) > 0 && cfg.KafkaBrokers[0] != "" {
+		topics := append(append([]string{}, cfg.KafkaMessageTopics...), cfg.KafkaMemberTopics...)
+		eventConsumer, err := db.NewKafkaConsumer(cfg.KafkaBrokers, cfg.KafkaGroupID, topics)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to create Kafka consumer, continuing without message events")
+		} else {
+			defer eventConsumer.Close()
+			go func() {
+				if err := eventConsumer.Consume(workerCtx, channelService.HandleEvent); err != nil {
+					logger.WithError(err).Error("Event consumer stopped")
+				}
+			}()
+			logger.WithField("topics", topics).Info("Consuming channel events")
+		}
+	}
+
+	go channelService.RunAutoArchive(workerCtx, cfg.AutoArchiveInterval, cfg.AutoArchiveWarningDays)
+	go channelService.RunAckReminders(workerCtx, cfg.AckReminderInterval, cfg.AckReminderDelay)
+	go channelService.RunVoiceReaper(workerCtx, cfg.VoiceReaperInterval, cfg.VoiceHeartbeatTimeout)
+	logger.WithField("interval", cfg.AutoArchiveInterval).Info("Auto-archive job started")
+
 	// Initialize router
 	router := api.NewRouter(channelService, cfg, logger)
 	logger.Info("HTTP router initialized")
@@ -121,6 +198,7 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down channel service...")
+	stopWorkers()
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -277,16 +355,447 @@ func runMigrations(db *sqlx.DB) error {
 			use_count INT DEFAULT 0,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			default_bookmarks TEXT,
+			default_permissions TEXT,
+			source_channel_id CHAR(36),
+			version INT NOT NULL DEFAULT 1,
+			workspace_id CHAR(36) NOT NULL DEFAULT '',
+			visibility VARCHAR(20) NOT NULL DEFAULT 'private',
 			INDEX idx_channel_templates_created_by (created_by),
-			INDEX idx_channel_templates_is_public (is_public)
+			INDEX idx_channel_templates_is_public (is_public),
+			INDEX idx_channel_templates_workspace (workspace_id, visibility),
+			INDEX idx_channel_templates_visibility (visibility, use_count)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_reactions (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			message_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			emoji VARCHAR(50) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE KEY unique_reaction (channel_id, message_id, user_id, emoji),
+			INDEX idx_reaction_message (channel_id, message_id),
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_settings (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL UNIQUE,
+			slow_mode_interval INT DEFAULT 0,
+			max_pins INT DEFAULT 50,
+			max_bookmarks INT DEFAULT 100,
+			allow_threads BOOLEAN DEFAULT TRUE,
+			allow_reactions BOOLEAN DEFAULT TRUE,
+			allow_invites BOOLEAN DEFAULT TRUE,
+			auto_archive_days INT DEFAULT 0,
+			default_notification VARCHAR(50) DEFAULT 'all',
+			custom_emoji BOOLEAN DEFAULT FALSE,
+			link_previews BOOLEAN DEFAULT TRUE,
+			member_limit INT DEFAULT 0,
+			voice_stage_mode BOOLEAN DEFAULT FALSE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS custom_emoji (
+			id CHAR(36) PRIMARY KEY,
+			workspace_id CHAR(36) NOT NULL,
+			shortcode VARCHAR(48) NOT NULL,
+			image_url VARCHAR(500) NOT NULL,
+			created_by CHAR(36) NOT NULL,
+			usage_count INT DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			UNIQUE KEY unique_custom_emoji (workspace_id, shortcode)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS custom_emoji_aliases (
+			emoji_id CHAR(36) NOT NULL,
+			workspace_id CHAR(36) NOT NULL,
+			alias VARCHAR(48) NOT NULL,
+			PRIMARY KEY (emoji_id, alias),
+			UNIQUE KEY unique_custom_emoji_alias (workspace_id, alias),
+			FOREIGN KEY (emoji_id) REFERENCES custom_emoji(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS custom_emoji_names (
+			emoji_id CHAR(36) NOT NULL,
+			workspace_id CHAR(36) NOT NULL,
+			name VARCHAR(48) NOT NULL,
+			PRIMARY KEY (emoji_id, name),
+			UNIQUE KEY unique_custom_emoji_name (workspace_id, name),
+			FOREIGN KEY (emoji_id) REFERENCES custom_emoji(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS emoji_usage (
+			workspace_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			emoji VARCHAR(50) NOT NULL,
+			use_count INT DEFAULT 0,
+			last_used_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (workspace_id, user_id, emoji)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_read_receipts (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			message_id CHAR(36) NOT NULL,
+			read_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE KEY unique_read_receipt (channel_id, user_id),
+			INDEX idx_receipt_message (channel_id, message_id),
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_messages (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			thread_id CHAR(36) NULL,
+			created_at TIMESTAMP NOT NULL,
+			deleted_at TIMESTAMP NULL,
+			INDEX idx_channel_messages_channel (channel_id, created_at),
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_counters (
+			channel_id CHAR(36) PRIMARY KEY,
+			message_count INT DEFAULT 0,
+			member_count INT DEFAULT 0,
+			last_message_at TIMESTAMP NULL,
+			last_activity_at TIMESTAMP NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_mentions (
+			message_id CHAR(36) NOT NULL,
+			channel_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (message_id, user_id),
+			INDEX idx_channel_mentions_user (user_id, channel_id, created_at),
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_daily_stats (
+			channel_id CHAR(36) NOT NULL,
+			day DATE NOT NULL,
+			messages INT NOT NULL DEFAULT 0,
+			thread_replies INT NOT NULL DEFAULT 0,
+			reactions INT NOT NULL DEFAULT 0,
+			joins INT NOT NULL DEFAULT 0,
+			leaves INT NOT NULL DEFAULT 0,
+			PRIMARY KEY (channel_id, day),
+			INDEX idx_channel_daily_stats_day (day),
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_daily_user_activity (
+			channel_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			day DATE NOT NULL,
+			messages INT NOT NULL DEFAULT 0,
+			reactions INT NOT NULL DEFAULT 0,
+			PRIMARY KEY (channel_id, day, user_id),
+			INDEX idx_channel_daily_user (channel_id, user_id, day),
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_activity_log (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			action VARCHAR(100) NOT NULL,
+			target_id CHAR(36),
+			details TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+			INDEX idx_activity_channel (channel_id, created_at),
+			INDEX idx_activity_user (user_id),
+			INDEX idx_activity_action (action)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_archive_warnings (
+			channel_id CHAR(36) PRIMARY KEY,
+			last_activity_at TIMESTAMP NOT NULL,
+			warned_at TIMESTAMP NOT NULL,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_invites (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			created_by CHAR(36) NOT NULL,
+			code VARCHAR(20) NOT NULL UNIQUE,
+			max_uses INT DEFAULT 0,
+			use_count INT DEFAULT 0,
+			expires_at TIMESTAMP NULL,
+			is_active BOOLEAN DEFAULT TRUE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+			INDEX idx_invite_channel (channel_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_waitlist (
+			channel_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			role ENUM('owner', 'admin', 'member') DEFAULT 'member',
+			added_by CHAR(36) NOT NULL,
+			created_at TIMESTAMP(3) NOT NULL,
+			PRIMARY KEY (channel_id, user_id),
+			INDEX idx_waitlist_order (channel_id, created_at),
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS starred_channels (
+			id CHAR(36) PRIMARY KEY,
+			user_id CHAR(36) NOT NULL,
+			channel_id CHAR(36) NOT NULL,
+			position INT DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+			UNIQUE KEY unique_star (user_id, channel_id),
+			INDEX idx_starred_user (user_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_sections (
+			id CHAR(36) PRIMARY KEY,
+			workspace_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			position INT DEFAULT 0,
+			is_collapsed BOOLEAN DEFAULT FALSE,
+			channel_ids JSON,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			INDEX idx_section_user (workspace_id, user_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_threads (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			message_id CHAR(36) NOT NULL,
+			title VARCHAR(255),
+			created_by CHAR(36) NOT NULL,
+			is_locked BOOLEAN DEFAULT FALSE,
+			is_resolved BOOLEAN DEFAULT FALSE,
+			reply_count INT DEFAULT 0,
+			last_reply_at TIMESTAMP NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+			INDEX idx_thread_channel (channel_id),
+			INDEX idx_thread_message (message_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_announcements (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			title VARCHAR(255) NOT NULL,
+			content TEXT NOT NULL,
+			priority ENUM('low', 'normal', 'high', 'urgent') DEFAULT 'normal',
+			author_id CHAR(36) NOT NULL,
+			is_pinned BOOLEAN DEFAULT FALSE,
+			requires_ack BOOLEAN DEFAULT FALSE,
+			ack_reminder_sent_at TIMESTAMP NULL,
+			expires_at TIMESTAMP NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+			INDEX idx_announcement_channel (channel_id),
+			INDEX idx_announcement_ack_reminder (requires_ack, ack_reminder_sent_at, created_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_announcement_acks (
+			announcement_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			acknowledged_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (announcement_id, user_id),
+			FOREIGN KEY (announcement_id) REFERENCES channel_announcements(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_bookmarks (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			title VARCHAR(255) NOT NULL,
+			url VARCHAR(2000),
+			entity_type VARCHAR(50),
+			entity_id CHAR(36),
+			position INT DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+			INDEX idx_bookmark_user (channel_id, user_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_topic_history (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			old_topic VARCHAR(500),
+			new_topic VARCHAR(500),
+			changed_by CHAR(36) NOT NULL,
+			changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+			INDEX idx_topic_history_channel (channel_id, changed_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_moderation_log (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			action VARCHAR(50) NOT NULL,
+			actor_id CHAR(36) NOT NULL,
+			reason TEXT,
+			expires_at TIMESTAMP NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+			INDEX idx_modlog_channel (channel_id, created_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_template_versions (
+			id CHAR(36) PRIMARY KEY,
+			template_id CHAR(36) NOT NULL,
+			version INT NOT NULL,
+			channel_type VARCHAR(20) DEFAULT 'public',
+			default_topic VARCHAR(500),
+			default_tabs TEXT,
+			default_settings TEXT,
+			default_bookmarks TEXT,
+			default_permissions TEXT,
+			source_channel_id CHAR(36),
+			created_by CHAR(36) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (template_id) REFERENCES channel_templates(id) ON DELETE CASCADE,
+			UNIQUE KEY uq_template_version (template_id, version)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS channel_permissions (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			permission_type VARCHAR(100) NOT NULL,
+			target_type ENUM('role', 'user') NOT NULL,
+			target_id VARCHAR(100) NOT NULL,
+			allow BOOLEAN DEFAULT FALSE,
+			deny BOOLEAN DEFAULT FALSE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+			UNIQUE KEY unique_perm (channel_id, permission_type, target_type, target_id),
+			INDEX idx_perm_channel (channel_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS voice_channel_states (
+			id CHAR(36) PRIMARY KEY,
+			channel_id CHAR(36) NOT NULL,
+			user_id CHAR(36) NOT NULL,
+			is_muted BOOLEAN DEFAULT FALSE,
+			is_deafened BOOLEAN DEFAULT FALSE,
+			is_screen_share BOOLEAN DEFAULT FALSE,
+			is_video_on BOOLEAN DEFAULT FALSE,
+			joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			disconnected_at TIMESTAMP NULL,
+			is_server_muted BOOLEAN DEFAULT FALSE,
+			is_server_deafened BOOLEAN DEFAULT FALSE,
+			is_speaker BOOLEAN DEFAULT FALSE,
+			hand_raised_at TIMESTAMP NULL,
+			FOREIGN KEY (channel_id) REFERENCES channels(id) ON DELETE CASCADE,
+			INDEX idx_voice_active (channel_id, disconnected_at),
+			INDEX idx_voice_channel_joined (channel_id, joined_at),
+			INDEX idx_voice_user (user_id, disconnected_at),
+			INDEX idx_voice_disconnected (disconnected_at, id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS processed_events (
+			event_id VARCHAR(64) PRIMARY KEY,
+			processed_at TIMESTAMP NOT NULL
 		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
 	}
 
+	// Backfills seed a new table from data that predates it, so each runs
+	// only when its table is created.
+	backfills := map[string]string{
+		// Existing channels have no activity recorded here, so their idle
+		// clock starts at deploy rather than at creation.
+		"channel_counters": `INSERT INTO channel_counters (channel_id, member_count, last_activity_at, updated_at)
+			SELECT c.id, (SELECT COUNT(*) FROM channel_members m WHERE m.channel_id = c.id), NOW(), NOW() FROM channels c`,
+		// Shortcodes and aliases share one namespace per workspace; names that
+		// already collide keep their first owner.
+		"custom_emoji_names": `INSERT IGNORE INTO custom_emoji_names (emoji_id, workspace_id, name)
+			SELECT id, workspace_id, shortcode FROM custom_emoji
+			UNION ALL
+			SELECT emoji_id, workspace_id, alias FROM custom_emoji_aliases`,
+	}
+	created := make(map[string]bool, len(backfills))
+	for table := range backfills {
+		exists, err := tableExists(db, table)
+		if err != nil {
+			return err
+		}
+		created[table] = !exists
+	}
+
 	for _, migration := range migrations {
 		if _, err := db.Exec(migration); err != nil {
 			return err
 		}
 	}
 
+	// Columns and indexes added to tables that existing databases already
+	// have, which CREATE TABLE IF NOT EXISTS leaves as they are. A column's
+	// backfill runs once, when the column is added.
+	columns := []struct{ table, column, definition, backfill string }{
+		{"channel_templates", "default_bookmarks", "TEXT", ""},
+		{"channel_templates", "default_permissions", "TEXT", ""},
+		{"channel_templates", "source_channel_id", "CHAR(36)", ""},
+		{"channel_templates", "version", "INT NOT NULL DEFAULT 1", ""},
+		{"channel_templates", "workspace_id", "CHAR(36) NOT NULL DEFAULT ''", ""},
+		{"channel_templates", "visibility", "VARCHAR(20) NOT NULL DEFAULT 'private'",
+			`UPDATE channel_templates SET visibility = 'global' WHERE is_public = TRUE`},
+		{"channel_settings", "voice_stage_mode", "BOOLEAN DEFAULT FALSE", ""},
+		{"voice_channel_states", "is_server_muted", "BOOLEAN DEFAULT FALSE", ""},
+		{"voice_channel_states", "is_server_deafened", "BOOLEAN DEFAULT FALSE", ""},
+		{"voice_channel_states", "is_speaker", "BOOLEAN DEFAULT FALSE", ""},
+		{"voice_channel_states", "hand_raised_at", "TIMESTAMP NULL", ""},
+		{"channel_announcements", "requires_ack", "BOOLEAN DEFAULT FALSE", ""},
+		{"channel_announcements", "ack_reminder_sent_at", "TIMESTAMP NULL", ""},
+	}
+	for _, col := range columns {
+		added, err := addColumnIfMissing(db, col.table, col.column, col.definition)
+		if err != nil {
+			return err
+		}
+		if added && col.backfill != "" {
+			if _, err := db.Exec(col.backfill); err != nil {
+				return err
+			}
+		}
+	}
+	indexes := []struct{ table, index, columns string }{
+		{"channel_templates", "idx_channel_templates_workspace", "(workspace_id, visibility)"},
+		{"channel_templates", "idx_channel_templates_visibility", "(visibility, use_count)"},
+		{"channel_announcements", "idx_announcement_ack_reminder", "(requires_ack, ack_reminder_sent_at, created_at)"},
+	}
+	for _, idx := range indexes {
+		if err := addIndexIfMissing(db, idx.table, idx.index, idx.columns); err != nil {
+			return err
+		}
+	}
+
+	for table, backfill := range backfills {
+		if !created[table] {
+			continue
+		}
+		if _, err := db.Exec(backfill); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// addColumnIfMissing adds the column unless it exists, reporting whether it
+// did.
+func addColumnIfMissing(db *sqlx.DB, table, column, definition string) (bool, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`
+	if err := db.Get(&count, query, table, column); err != nil || count > 0 {
+		return false, err
+	}
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func addIndexIfMissing(db *sqlx.DB, table, index, columns string) error {
+	var count int
+	query := `SELECT COUNT(*) FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?`
+	if err := db.Get(&count, query, table, index); err != nil || count > 0 {
+		return err
+	}
+	_, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD INDEX %s %s", table, index, columns))
+	return err
+}
+
+func tableExists(db *sqlx.DB, table string) (bool, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`
+	err := db.Get(&count, query, table)
+	return count > 0, err
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/quckapp/channel-service/internal/service"
+)
+
+// ── Activity Log ──
+
+func (h *ChannelHandler) GetActivityLog(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	filter := &models.ActivityLogFilter{
+		UserID:   c.Query("actor"),
+		Action:   c.Query("action"),
+		TargetID: c.Query("target"),
+	}
+	from, err := parseDateParam(c.Query("from"))
+	if err != nil {
+		handleError(c, service.ErrInvalidTimeRange)
+		return
+	}
+	to, err := parseDateParam(c.Query("to"))
+	if err != nil {
+		handleError(c, service.ErrInvalidTimeRange)
+		return
+	}
+	if !from.IsZero() {
+		filter.From = &from
+	}
+	if !to.IsZero() {
+		filter.To = &to
+	}
+
+	page, err := h.service.GetActivityLog(c.Request.Context(), channelID, userID, filter, c.Query("cursor"), limit)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
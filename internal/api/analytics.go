@@ -0,0 +1,104 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/service"
+)
+
+// ── Analytics ──
+
+func (h *ChannelHandler) GetChannelStats(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	stats, err := h.service.GetChannelStats(c.Request.Context(), channelID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *ChannelHandler) GetActivityTimeSeries(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	from, err := parseDateParam(c.Query("from"))
+	if err != nil {
+		handleError(c, service.ErrInvalidTimeRange)
+		return
+	}
+	to, err := parseDateParam(c.Query("to"))
+	if err != nil {
+		handleError(c, service.ErrInvalidTimeRange)
+		return
+	}
+
+	points, err := h.service.GetActivityTimeSeries(c.Request.Context(), channelID, userID, c.Query("granularity"), from, to)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"series": points})
+}
+
+func (h *ChannelHandler) GetRetentionCohorts(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	weeks, _ := strconv.Atoi(c.Query("weeks"))
+
+	cohorts, err := h.service.GetRetentionCohorts(c.Request.Context(), channelID, userID, weeks)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"cohorts": cohorts})
+}
+
+func (h *ChannelHandler) GetTopPosters(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	days, _ := strconv.Atoi(c.Query("days"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	posters, err := h.service.GetTopPosters(c.Request.Context(), channelID, userID, days, limit)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"posters": posters})
+}
+
+// parseDateParam accepts either a plain date or an RFC 3339 timestamp.
+// An empty value yields the zero time.
+func parseDateParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func (h *ChannelHandler) GetWorkspaceAnalytics(c *gin.Context) {
+	workspaceID := c.Param("id")
+	days, _ := strconv.Atoi(c.Query("days"))
+	dormantDays, _ := strconv.Atoi(c.Query("dormant_days"))
+
+	analytics, err := h.service.GetWorkspaceAnalytics(c.Request.Context(), workspaceID, c.Query("granularity"), days, dormantDays)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Announcements ──
+
+func (h *ChannelHandler) CreateAnnouncement(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	var req models.CreateAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ann, err := h.service.CreateAnnouncement(c.Request.Context(), channelID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ann)
+}
+
+func (h *ChannelHandler) ListAnnouncements(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	includeExpired := c.Query("include_expired") == "true"
+
+	anns, err := h.service.ListAnnouncements(c.Request.Context(), channelID, userID, includeExpired)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"announcements": anns})
+}
+
+func (h *ChannelHandler) GetAnnouncement(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	announcementID := c.Param("announcementId")
+
+	ann, err := h.service.GetAnnouncement(c.Request.Context(), channelID, announcementID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ann)
+}
+
+func (h *ChannelHandler) UpdateAnnouncement(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	announcementID := c.Param("announcementId")
+
+	var req models.UpdateAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ann, err := h.service.UpdateAnnouncement(c.Request.Context(), channelID, announcementID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ann)
+}
+
+func (h *ChannelHandler) DeleteAnnouncement(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	announcementID := c.Param("announcementId")
+
+	if err := h.service.DeleteAnnouncement(c.Request.Context(), channelID, announcementID, userID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ChannelHandler) PinAnnouncement(c *gin.Context) {
+	h.setAnnouncementPinned(c, true)
+}
+
+func (h *ChannelHandler) UnpinAnnouncement(c *gin.Context) {
+	h.setAnnouncementPinned(c, false)
+}
+
+func (h *ChannelHandler) setAnnouncementPinned(c *gin.Context, pinned bool) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	announcementID := c.Param("announcementId")
+
+	ann, err := h.service.SetAnnouncementPinned(c.Request.Context(), channelID, announcementID, userID, pinned)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ann)
+}
+
+func (h *ChannelHandler) AcknowledgeAnnouncement(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	announcementID := c.Param("announcementId")
+
+	ack, err := h.service.AcknowledgeAnnouncement(c.Request.Context(), channelID, announcementID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ack)
+}
+
+func (h *ChannelHandler) GetAnnouncementAckReport(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	announcementID := c.Param("announcementId")
+
+	report, err := h.service.GetAnnouncementAckReport(c.Request.Context(), channelID, announcementID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
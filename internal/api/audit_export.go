@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/quckapp/channel-service/internal/service"
+)
+
+// ── Audit Export ──
+
+const (
+	// auditFlushEvery is how many records are written between flushes.
+	auditFlushEvery = 500
+	// auditWriteWindow replaces the server's write timeout while streaming:
+	// each flush must complete within it, but the export as a whole may
+	// run for as long as it keeps making progress.
+	auditWriteWindow = 30 * time.Second
+)
+
+var auditCSVHeader = []string{
+	"source", "id", "channel_id", "actor_id", "action", "target_id",
+	"subject_user_id", "reason", "expires_at", "details", "created_at",
+}
+
+func (h *ChannelHandler) ExportWorkspaceAudit(c *gin.Context) {
+	workspaceID := c.Param("id")
+
+	format := c.DefaultQuery("format", "ndjson")
+	if format != "ndjson" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be ndjson or csv"})
+		return
+	}
+	from, err := parseDateParam(c.Query("from"))
+	if err != nil {
+		handleError(c, service.ErrInvalidTimeRange)
+		return
+	}
+	to, err := parseDateParam(c.Query("to"))
+	if err != nil {
+		handleError(c, service.ErrInvalidTimeRange)
+		return
+	}
+
+	w := &auditExportWriter{c: c, rc: http.NewResponseController(c.Writer), format: format, workspaceID: workspaceID}
+	err = h.service.ExportWorkspaceAudit(c.Request.Context(), workspaceID, from, to, w.write)
+	if err != nil && !w.started {
+		handleError(c, err)
+		return
+	}
+	if err != nil {
+		// Headers are already sent, so the client sees a truncated body.
+		h.logger.WithError(err).WithField("workspace_id", workspaceID).Error("Audit export aborted")
+		return
+	}
+	w.start()
+	w.flush()
+}
+
+// auditExportWriter sends headers on the first record, so errors raised
+// before any output can still become a normal error response.
+type auditExportWriter struct {
+	c           *gin.Context
+	rc          *http.ResponseController
+	format      string
+	workspaceID string
+	started     bool
+	count       int
+	csv         *csv.Writer
+	json        *json.Encoder
+}
+
+func (w *auditExportWriter) start() {
+	if w.started {
+		return
+	}
+	w.started = true
+	w.extendDeadline()
+
+	filename := fmt.Sprintf("audit-%s-%s.%s", w.workspaceID, time.Now().UTC().Format("20060102T150405Z"), w.format)
+	header := w.c.Writer.Header()
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	header.Set("Cache-Control", "no-store")
+	if w.format == "csv" {
+		header.Set("Content-Type", "text/csv; charset=utf-8")
+		w.csv = csv.NewWriter(w.c.Writer)
+		w.csv.Write(auditCSVHeader)
+	} else {
+		header.Set("Content-Type", "application/x-ndjson")
+		w.json = json.NewEncoder(w.c.Writer)
+	}
+	w.c.Status(http.StatusOK)
+}
+
+func (w *auditExportWriter) write(record *models.AuditRecord) error {
+	w.start()
+
+	var err error
+	if w.csv != nil {
+		err = w.csv.Write(auditCSVRow(record))
+	} else {
+		err = w.json.Encode(record)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.count++
+	if w.count%auditFlushEvery == 0 {
+		w.flush()
+	}
+	return nil
+}
+
+func (w *auditExportWriter) flush() {
+	if w.csv != nil {
+		w.csv.Flush()
+	}
+	w.c.Writer.Flush()
+	w.extendDeadline()
+}
+
+// extendDeadline pushes the write deadline out by auditWriteWindow, so a
+// large export is not cut off by the server's WriteTimeout.
+func (w *auditExportWriter) extendDeadline() {
+	// Writers without deadline support have no timeout to extend.
+	_ = w.rc.SetWriteDeadline(time.Now().Add(auditWriteWindow))
+}
+
+func auditCSVRow(r *models.AuditRecord) []string {
+	var expiresAt string
+	if r.ExpiresAt != nil {
+		expiresAt = r.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		r.Source,
+		r.ID,
+		r.ChannelID,
+		csvSafe(r.ActorID),
+		csvSafe(r.Action),
+		csvSafe(deref(r.TargetID)),
+		csvSafe(deref(r.SubjectUserID)),
+		csvSafe(deref(r.Reason)),
+		expiresAt,
+		csvSafe(deref(r.Details)),
+		r.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// csvSafe neutralizes values a spreadsheet would evaluate as a formula.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ── Auto-Archive ──
+
+func (h *ChannelHandler) PreviewAutoArchive(c *gin.Context) {
+	workspaceID := c.Param("id")
+	// Default to the sweep's own setting so the dry run matches it.
+	warningDays := h.cfg.AutoArchiveWarningDays
+	if v, err := strconv.Atoi(c.Query("warning_days")); err == nil {
+		warningDays = v
+	}
+
+	candidates, err := h.service.PreviewAutoArchive(c.Request.Context(), workspaceID, warningDays)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to preview auto-archive"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"channels": candidates})
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Bookmarks ──
+
+func (h *ChannelHandler) CreateBookmark(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	var req models.CreateBookmarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bookmark, err := h.service.CreateBookmark(c.Request.Context(), channelID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, bookmark)
+}
+
+func (h *ChannelHandler) ListBookmarks(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	bookmarks, err := h.service.ListBookmarks(c.Request.Context(), channelID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"bookmarks": bookmarks})
+}
+
+func (h *ChannelHandler) UpdateBookmark(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	bookmarkID := c.Param("bookmarkId")
+
+	var req models.UpdateBookmarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bookmark, err := h.service.UpdateBookmark(c.Request.Context(), channelID, bookmarkID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bookmark)
+}
+
+func (h *ChannelHandler) DeleteBookmark(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	bookmarkID := c.Param("bookmarkId")
+
+	if err := h.service.DeleteBookmark(c.Request.Context(), channelID, bookmarkID, userID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ChannelHandler) ReorderBookmarks(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	var req models.ReorderBookmarksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bookmarks, err := h.service.ReorderBookmarks(c.Request.Context(), channelID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"bookmarks": bookmarks})
+}
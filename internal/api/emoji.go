@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Custom Emoji ──
+
+func (h *ChannelHandler) CreateCustomEmoji(c *gin.Context) {
+	userID := getUserID(c)
+	workspaceID := c.Param("id")
+
+	var req models.CreateCustomEmojiRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	emoji, err := h.service.CreateCustomEmoji(c.Request.Context(), workspaceID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, emoji)
+}
+
+func (h *ChannelHandler) ListCustomEmoji(c *gin.Context) {
+	workspaceID := c.Param("id")
+
+	emojis, err := h.service.ListCustomEmoji(c.Request.Context(), workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list custom emoji"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"emoji": emojis})
+}
+
+func (h *ChannelHandler) GetCustomEmoji(c *gin.Context) {
+	workspaceID := c.Param("id")
+
+	emoji, err := h.service.GetCustomEmoji(c.Request.Context(), workspaceID, c.Param("name"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, emoji)
+}
+
+func (h *ChannelHandler) DeleteCustomEmoji(c *gin.Context) {
+	workspaceID := c.Param("id")
+	emojiID := c.Param("emojiId")
+
+	if err := h.service.DeleteCustomEmoji(c.Request.Context(), workspaceID, emojiID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ChannelHandler) ListFrequentEmoji(c *gin.Context) {
+	userID := getUserID(c)
+	workspaceID := c.Param("id")
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	usage, err := h.service.ListFrequentEmoji(c.Request.Context(), workspaceID, userID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list frequently used emoji"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"emoji": usage})
+}
@@ -2,8 +2,10 @@ package api
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/config"
 	"github.com/quckapp/channel-service/internal/models"
 	"github.com/quckapp/channel-service/internal/service"
 	"github.com/sirupsen/logrus"
@@ -11,11 +13,12 @@ import (
 
 type ChannelHandler struct {
 	service *service.ChannelService
+	cfg     *config.Config
 	logger  *logrus.Logger
 }
 
-func NewChannelHandler(svc *service.ChannelService, logger *logrus.Logger) *ChannelHandler {
-	return &ChannelHandler{service: svc, logger: logger}
+func NewChannelHandler(svc *service.ChannelService, cfg *config.Config, logger *logrus.Logger) *ChannelHandler {
+	return &ChannelHandler{service: svc, cfg: cfg, logger: logger}
 }
 
 // ── Polls ──
@@ -384,7 +387,7 @@ func (h *ChannelHandler) CreateTemplate(c *gin.Context) {
 		return
 	}
 
-	tmpl, err := h.service.CreateTemplate(c.Request.Context(), channelID, userID, &req)
+	tmpl, err := h.service.CreateTemplate(c.Request.Context(), channelID, userID, getWorkspaceScope(c), &req)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -393,14 +396,84 @@ func (h *ChannelHandler) CreateTemplate(c *gin.Context) {
 	c.JSON(http.StatusCreated, tmpl)
 }
 
+func (h *ChannelHandler) CreateTemplateVersion(c *gin.Context) {
+	userID := getUserID(c)
+	templateID := c.Param("templateId")
+
+	var req models.CreateTemplateVersionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tmpl, err := h.service.CreateTemplateVersion(c.Request.Context(), templateID, userID, getWorkspaceScope(c), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tmpl)
+}
+
+func (h *ChannelHandler) ListTemplateVersions(c *gin.Context) {
+	userID := getUserID(c)
+	templateID := c.Param("templateId")
+
+	versions, err := h.service.ListTemplateVersions(c.Request.Context(), templateID, userID, getWorkspaceScope(c))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"versions": versions})
+}
+
+// ListTemplates lists the global templates and the caller's own.
 func (h *ChannelHandler) ListTemplates(c *gin.Context) {
-	templates, err := h.service.ListTemplates(c.Request.Context())
+	h.listTemplates(c, "")
+}
+
+// ListWorkspaceTemplates lists the templates usable in a workspace.
+func (h *ChannelHandler) ListWorkspaceTemplates(c *gin.Context) {
+	h.listTemplates(c, c.Param("id"))
+}
+
+func (h *ChannelHandler) listTemplates(c *gin.Context, workspaceID string) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	filter := &models.TemplateFilter{
+		UserID:      getUserID(c),
+		WorkspaceID: workspaceID,
+		Query:       c.Query("q"),
+		Sort:        c.Query("sort"),
+	}
+
+	page, err := h.service.ListTemplates(c.Request.Context(), filter, limit, offset)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *ChannelHandler) UpdateTemplate(c *gin.Context) {
+	userID := getUserID(c)
+	templateID := c.Param("templateId")
+
+	var req models.UpdateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tmpl, err := h.service.UpdateTemplate(c.Request.Context(), templateID, userID, getWorkspaceScope(c), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list templates"})
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"templates": templates})
+	c.JSON(http.StatusOK, tmpl)
 }
 
 func (h *ChannelHandler) ApplyTemplate(c *gin.Context) {
@@ -413,20 +486,20 @@ func (h *ChannelHandler) ApplyTemplate(c *gin.Context) {
 		return
 	}
 
-	tmpl, err := h.service.ApplyTemplate(c.Request.Context(), templateID, userID, &req)
+	channel, err := h.service.ApplyTemplate(c.Request.Context(), templateID, userID, getWorkspaceScope(c), &req)
 	if err != nil {
 		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, tmpl)
+	c.JSON(http.StatusCreated, channel)
 }
 
 func (h *ChannelHandler) DeleteTemplate(c *gin.Context) {
 	userID := getUserID(c)
 	templateID := c.Param("templateId")
 
-	if err := h.service.DeleteTemplate(c.Request.Context(), templateID, userID); err != nil {
+	if err := h.service.DeleteTemplate(c.Request.Context(), templateID, userID, getWorkspaceScope(c)); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -441,6 +514,10 @@ func getUserID(c *gin.Context) string {
 	return userIDStr.(string)
 }
 
+func getWorkspaceScope(c *gin.Context) service.WorkspaceScope {
+	return service.WorkspaceScope{WorkspaceID: c.GetString("workspace_id"), Role: c.GetString("role")}
+}
+
 func handleError(c *gin.Context, err error) {
 	switch err {
 	case service.ErrPollNotFound:
@@ -463,6 +540,104 @@ func handleError(c *gin.Context, err error) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Not following this channel"})
 	case service.ErrChannelTemplateNotFound:
 		c.JSON(http.StatusNotFound, gin.H{"error": "Channel template not found"})
+	case service.ErrReactionsDisabled:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Reactions are disabled in this channel"})
+	case service.ErrCustomEmojiDisabled:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Custom emoji are disabled in this channel"})
+	case service.ErrTooManyMessageIDs:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At most 100 message IDs may be requested at once"})
+	case service.ErrChannelNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Channel not found"})
+	case service.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+	case service.ErrInvalidEmoji:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Emoji must be a unicode emoji or a registered custom emoji"})
+	case service.ErrInvalidEmojiShortcode:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Shortcodes may only contain lowercase letters, digits, _, + and -"})
+	case service.ErrEmojiShortcodeTaken:
+		c.JSON(http.StatusConflict, gin.H{"error": "Emoji shortcode already in use"})
+	case service.ErrCustomEmojiNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Custom emoji not found"})
+	case service.ErrNotChannelMember:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not a member of this channel"})
+	case service.ErrMessageNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Message not found"})
+	case service.ErrInvalidGranularity:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Granularity must be day, week or month"})
+	case service.ErrInvalidTimeRange:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time range"})
+	case service.ErrChannelFull:
+		c.JSON(http.StatusConflict, gin.H{"error": "Channel has reached its member limit", "code": "channel_full"})
+	case service.ErrChannelArchived:
+		c.JSON(http.StatusConflict, gin.H{"error": "Channel is archived"})
+	case service.ErrAlreadyMember:
+		c.JSON(http.StatusConflict, gin.H{"error": "Already a member of this channel"})
+	case service.ErrMemberNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+	case service.ErrInviteNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invite not found"})
+	case service.ErrInviteExhausted:
+		c.JSON(http.StatusGone, gin.H{"error": "Invite has expired or reached its maximum uses"})
+	case service.ErrNotWaitlisted:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not on the waitlist"})
+	case service.ErrStarLimitReached:
+		c.JSON(http.StatusConflict, gin.H{"error": "Starred channel limit reached"})
+	case service.ErrAlreadyStarred:
+		c.JSON(http.StatusConflict, gin.H{"error": "Channel is already starred"})
+	case service.ErrNotStarred:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Channel is not starred"})
+	case service.ErrInvalidStarOrder:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Order must list every starred channel exactly once"})
+	case service.ErrSectionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Section not found"})
+	case service.ErrInvalidSectionOrder:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Order must list every section exactly once"})
+	case service.ErrWorkspaceMismatch:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Channel belongs to a different workspace"})
+	case service.ErrBookmarkNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bookmark not found"})
+	case service.ErrBookmarkLimitReached:
+		c.JSON(http.StatusConflict, gin.H{"error": "Bookmark limit reached for this channel"})
+	case service.ErrInvalidBookmarkTarget:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bookmark needs either a url or an entity_type and entity_id"})
+	case service.ErrInvalidURL:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "URL must be an absolute http or https URL"})
+	case service.ErrBookmarkEntityNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bookmarked entity not found in this channel"})
+	case service.ErrInvalidBookmarkOrder:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Order must list every bookmark exactly once"})
+	case service.ErrBookmarkTitleRequired:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bookmark title must not be blank"})
+	case service.ErrLinkPreviewsDisabled:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Link previews are disabled in this channel"})
+	case service.ErrLinkPreviewUnavailable:
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Link preview could not be fetched"})
+	case service.ErrAnnouncementNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Announcement not found"})
+	case service.ErrInvalidExpiry:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_at must be in the future"})
+	case service.ErrTopicHistoryNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Topic history entry not found"})
+	case service.ErrChannelNameTaken:
+		c.JSON(http.StatusConflict, gin.H{"error": "Channel name already in use"})
+	case service.ErrInvalidCursor:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+	case service.ErrNotInVoiceChannel:
+		c.JSON(http.StatusConflict, gin.H{"error": "Not connected to this voice channel"})
+	case service.ErrVoiceStageAudience:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only speakers may unmute in stage mode"})
+	case service.ErrVoiceStageModeOff:
+		c.JSON(http.StatusConflict, gin.H{"error": "Stage mode is not enabled in this channel"})
+	case service.ErrInvalidTemplate:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Template defaults are malformed"})
+	case service.ErrTemplateVersionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template version not found"})
+	case service.ErrTemplateSourceType:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Only public and private channels can be saved as templates"})
+	case service.ErrInvalidTemplateSort:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Sort must be popular or recent"})
+	case service.ErrChannelNameRequired:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Channel name must not be blank"})
 	default:
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 	}
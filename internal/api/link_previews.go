@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ── Link Previews ──
+
+func (h *ChannelHandler) GetLinkPreview(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	rawURL := c.Query("url")
+	if rawURL == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "url is required"})
+		return
+	}
+
+	preview, err := h.service.GetLinkPreview(c.Request.Context(), channelID, userID, rawURL)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Membership & Capacity ──
+
+func (h *ChannelHandler) AddMember(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	var req models.AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.AddMember(c.Request.Context(), channelID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	respondJoin(c, result)
+}
+
+func (h *ChannelHandler) BulkAddMembers(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	var req models.BulkAddMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.BulkAddMembers(c.Request.Context(), channelID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ChannelHandler) RemoveMember(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	if err := h.service.RemoveMember(c.Request.Context(), channelID, userID, c.Param("userId")); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ChannelHandler) ListWaitlist(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	entries, err := h.service.ListWaitlist(c.Request.Context(), channelID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"waitlist": entries})
+}
+
+func (h *ChannelHandler) RemoveFromWaitlist(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	if err := h.service.RemoveFromWaitlist(c.Request.Context(), channelID, userID, c.Param("userId")); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ChannelHandler) CreateInvite(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	var req models.CreateInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invite, err := h.service.CreateInvite(c.Request.Context(), channelID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invite)
+}
+
+func (h *ChannelHandler) RedeemInvite(c *gin.Context) {
+	userID := getUserID(c)
+
+	var req models.RedeemInviteRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	result, err := h.service.RedeemInvite(c.Request.Context(), c.Param("code"), userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	respondJoin(c, result)
+}
+
+// respondJoin answers 201 for a join and 202 for a waitlisted request.
+func respondJoin(c *gin.Context, result *models.JoinResult) {
+	if result.Waitlisted {
+		c.JSON(http.StatusAccepted, result)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
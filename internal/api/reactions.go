@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Reactions ──
+
+func (h *ChannelHandler) ToggleReaction(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	messageID := c.Param("messageId")
+
+	var req models.ToggleReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.ToggleReaction(c.Request.Context(), channelID, messageID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ChannelHandler) ListReactors(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	messageID := c.Param("messageId")
+
+	reactions, err := h.service.ListReactors(c.Request.Context(), channelID, messageID, userID, c.Query("emoji"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reactions": reactions})
+}
+
+func (h *ChannelHandler) GetReactionSummaries(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	var messageIDs []string
+	for _, value := range c.QueryArray("message_ids") {
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				messageIDs = append(messageIDs, id)
+			}
+		}
+	}
+
+	summaries, err := h.service.GetReactionSummaries(c.Request.Context(), channelID, userID, messageIDs)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"summaries": summaries})
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Read Receipts & Unread ──
+
+func (h *ChannelHandler) MarkRead(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	var req models.MarkReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	receipt, err := h.service.MarkRead(c.Request.Context(), channelID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
+
+func (h *ChannelHandler) GetMessageReaders(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	messageID := c.Param("messageId")
+
+	readers, err := h.service.GetMessageReaders(c.Request.Context(), channelID, messageID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, readers)
+}
+
+func (h *ChannelHandler) GetUnreadSummary(c *gin.Context) {
+	userID := getUserID(c)
+
+	summary, err := h.service.GetUnreadSummary(c.Request.Context(), userID, c.Query("workspace_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get unread summary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
@@ -29,11 +29,16 @@ func NewRouter(
 
 	api := r.Group("/api/v1")
 	{
-		handler := NewChannelHandler(channelService, logger)
+		handler := NewChannelHandler(channelService, cfg, logger)
 
 		channels := api.Group("/channels")
 		channels.Use(middleware.Auth(cfg.JWTSecret))
 		{
+			// Channel Details
+			channels.PATCH("/:id", handler.UpdateChannel)
+			channels.GET("/:id/topic-history", handler.GetTopicHistory)
+			channels.POST("/:id/topic/revert", handler.RevertTopic)
+
 			// Polls
 			channels.POST("/:id/polls", handler.CreatePoll)
 			channels.GET("/:id/polls", handler.ListPolls)
@@ -68,13 +73,125 @@ func NewRouter(
 			channels.GET("/:id/followers", handler.ListFollowers)
 			channels.GET("/:id/followers/check", handler.CheckFollowing)
 
+			// Reactions
+			channels.POST("/:id/messages/:messageId/reactions", handler.ToggleReaction)
+			channels.GET("/:id/messages/:messageId/reactions", handler.ListReactors)
+			channels.GET("/:id/reactions/summary", handler.GetReactionSummaries)
+
+			// Read Receipts
+			channels.POST("/:id/read", handler.MarkRead)
+			channels.GET("/:id/messages/:messageId/readers", handler.GetMessageReaders)
+
+			// Members
+			channels.POST("/:id/members", handler.AddMember)
+			channels.POST("/:id/members/bulk", handler.BulkAddMembers)
+			channels.DELETE("/:id/members/:userId", handler.RemoveMember)
+			channels.GET("/:id/waitlist", handler.ListWaitlist)
+			channels.DELETE("/:id/waitlist/:userId", handler.RemoveFromWaitlist)
+			channels.POST("/:id/invites", handler.CreateInvite)
+
+			// Starred
+			channels.POST("/:id/star", handler.StarChannel)
+			channels.DELETE("/:id/star", handler.UnstarChannel)
+
+			// Bookmarks
+			channels.POST("/:id/bookmarks", handler.CreateBookmark)
+			channels.GET("/:id/bookmarks", handler.ListBookmarks)
+			channels.PUT("/:id/bookmarks/order", handler.ReorderBookmarks)
+			channels.PATCH("/:id/bookmarks/:bookmarkId", handler.UpdateBookmark)
+			channels.DELETE("/:id/bookmarks/:bookmarkId", handler.DeleteBookmark)
+
+			// Announcements
+			channels.POST("/:id/announcements", handler.CreateAnnouncement)
+			channels.GET("/:id/announcements", handler.ListAnnouncements)
+			channels.GET("/:id/announcements/:announcementId", handler.GetAnnouncement)
+			channels.PATCH("/:id/announcements/:announcementId", handler.UpdateAnnouncement)
+			channels.DELETE("/:id/announcements/:announcementId", handler.DeleteAnnouncement)
+			channels.POST("/:id/announcements/:announcementId/pin", handler.PinAnnouncement)
+			channels.DELETE("/:id/announcements/:announcementId/pin", handler.UnpinAnnouncement)
+			channels.POST("/:id/announcements/:announcementId/ack", handler.AcknowledgeAnnouncement)
+			channels.GET("/:id/announcements/:announcementId/acks", handler.GetAnnouncementAckReport)
+
+			// Link Previews
+			channels.GET("/:id/link-preview", handler.GetLinkPreview)
+
+			// Voice
+			channels.POST("/:id/voice/join", handler.JoinVoiceChannel)
+			channels.POST("/:id/voice/leave", handler.LeaveVoiceChannel)
+			channels.PATCH("/:id/voice/state", handler.UpdateVoiceState)
+			channels.POST("/:id/voice/heartbeat", handler.VoiceHeartbeat)
+			channels.GET("/:id/voice/participants", handler.ListVoiceParticipants)
+			channels.POST("/:id/voice/hand", handler.RaiseHand)
+			channels.DELETE("/:id/voice/hand", handler.LowerHand)
+			channels.PATCH("/:id/voice/participants/:userId", handler.ModerateVoiceParticipant)
+			channels.POST("/:id/voice/participants/:userId/disconnect", handler.DisconnectVoiceParticipant)
+			channels.POST("/:id/voice/participants/:userId/move", handler.MoveVoiceParticipant)
+			channels.GET("/:id/voice/sessions", handler.ListChannelVoiceSessions)
+			channels.GET("/:id/voice/report", handler.GetVoiceUsageReport)
+
+			// Settings
+			channels.GET("/:id/settings", handler.GetChannelSettings)
+			channels.PATCH("/:id/settings", handler.UpdateChannelSettings)
+
+			// Activity Log
+			channels.GET("/:id/activity", handler.GetActivityLog)
+
+			// Analytics
+			channels.GET("/:id/analytics", handler.GetChannelStats)
+			channels.GET("/:id/analytics/timeseries", handler.GetActivityTimeSeries)
+			channels.GET("/:id/analytics/retention", handler.GetRetentionCohorts)
+			channels.GET("/:id/analytics/top-posters", handler.GetTopPosters)
+
 			// Templates (channel-scoped)
 			channels.POST("/:id/template", handler.CreateTemplate)
 		}
 
+		workspaces := api.Group("/workspaces")
+		workspaces.Use(middleware.Auth(cfg.JWTSecret))
+		{
+			// Custom Emoji
+			workspaces.POST("/:id/emoji", middleware.RequireWorkspace("admin", "owner"), handler.CreateCustomEmoji)
+			workspaces.GET("/:id/emoji", middleware.RequireWorkspace(), handler.ListCustomEmoji)
+			workspaces.GET("/:id/emoji/frequent", middleware.RequireWorkspace(), handler.ListFrequentEmoji)
+			workspaces.GET("/:id/emoji/name/:name", middleware.RequireWorkspace(), handler.GetCustomEmoji)
+			workspaces.DELETE("/:id/emoji/:emojiId", middleware.RequireWorkspace("admin", "owner"), handler.DeleteCustomEmoji)
+
+			// Analytics
+			workspaces.GET("/:id/analytics", middleware.RequireWorkspace("admin", "owner"), handler.GetWorkspaceAnalytics)
+
+			// Auto-Archive
+			workspaces.GET("/:id/auto-archive/preview", middleware.RequireWorkspace("admin", "owner"), handler.PreviewAutoArchive)
+
+			// Audit Export
+			workspaces.GET("/:id/audit/export", middleware.RequireWorkspace("admin", "owner"), handler.ExportWorkspaceAudit)
+
+			// Template Catalog
+			workspaces.GET("/:id/templates", middleware.RequireWorkspace(), handler.ListWorkspaceTemplates)
+		}
+
+		sections := api.Group("/sections")
+		sections.Use(middleware.Auth(cfg.JWTSecret))
+		{
+			sections.POST("", handler.CreateSection)
+			sections.PATCH("/:sectionId", handler.UpdateSection)
+			sections.DELETE("/:sectionId", handler.DeleteSection)
+			sections.PUT("/order", handler.ReorderSections)
+			sections.PUT("/channels/:channelId", handler.MoveChannel)
+		}
+
+		api.GET("/sidebar", middleware.Auth(cfg.JWTSecret), handler.GetSidebar)
+		api.GET("/unread", middleware.Auth(cfg.JWTSecret), handler.GetUnreadSummary)
+		api.POST("/invites/:code/redeem", middleware.Auth(cfg.JWTSecret), handler.RedeemInvite)
+		api.GET("/starred", middleware.Auth(cfg.JWTSecret), handler.ListStarredChannels)
+		api.PUT("/starred/order", middleware.Auth(cfg.JWTSecret), handler.ReorderStarredChannels)
+		api.GET("/voice/sessions", middleware.Auth(cfg.JWTSecret), handler.ListMyVoiceSessions)
+
 		// Templates (standalone routes outside /:id)
 		api.GET("/templates", middleware.Auth(cfg.JWTSecret), handler.ListTemplates)
 		api.POST("/templates/:templateId/apply", middleware.Auth(cfg.JWTSecret), handler.ApplyTemplate)
+		api.POST("/templates/:templateId/versions", middleware.Auth(cfg.JWTSecret), handler.CreateTemplateVersion)
+		api.GET("/templates/:templateId/versions", middleware.Auth(cfg.JWTSecret), handler.ListTemplateVersions)
+		api.PATCH("/templates/:templateId", middleware.Auth(cfg.JWTSecret), handler.UpdateTemplate)
 		api.DELETE("/templates/:templateId", middleware.Auth(cfg.JWTSecret), handler.DeleteTemplate)
 	}
 
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Sidebar Sections ──
+
+func (h *ChannelHandler) CreateSection(c *gin.Context) {
+	userID := getUserID(c)
+
+	var req models.CreateSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	section, err := h.service.CreateSection(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create section"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, section)
+}
+
+func (h *ChannelHandler) UpdateSection(c *gin.Context) {
+	userID := getUserID(c)
+	sectionID := c.Param("sectionId")
+
+	var req models.UpdateSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	section, err := h.service.UpdateSection(c.Request.Context(), sectionID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, section)
+}
+
+func (h *ChannelHandler) DeleteSection(c *gin.Context) {
+	userID := getUserID(c)
+	sectionID := c.Param("sectionId")
+
+	if err := h.service.DeleteSection(c.Request.Context(), sectionID, userID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ChannelHandler) ReorderSections(c *gin.Context) {
+	userID := getUserID(c)
+
+	var req models.ReorderSectionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sections, err := h.service.ReorderSections(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sections": sections})
+}
+
+func (h *ChannelHandler) MoveChannel(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("channelId")
+
+	var req models.MoveChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.MoveChannel(c.Request.Context(), channelID, userID, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ChannelHandler) GetSidebar(c *gin.Context) {
+	userID := getUserID(c)
+
+	workspaceID := c.Query("workspace_id")
+	if workspaceID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "workspace_id is required"})
+		return
+	}
+
+	sidebar, err := h.service.GetSidebar(c.Request.Context(), userID, workspaceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load sidebar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, sidebar)
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Channel Settings ──
+
+func (h *ChannelHandler) GetChannelSettings(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	settings, err := h.service.GetChannelSettings(c.Request.Context(), channelID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+func (h *ChannelHandler) UpdateChannelSettings(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	var req models.UpdateChannelSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.service.UpdateChannelSettings(c.Request.Context(), channelID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
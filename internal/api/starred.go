@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Starred Channels ──
+
+func (h *ChannelHandler) StarChannel(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	star, err := h.service.StarChannel(c.Request.Context(), channelID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, star)
+}
+
+func (h *ChannelHandler) UnstarChannel(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	if err := h.service.UnstarChannel(c.Request.Context(), channelID, userID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ChannelHandler) ListStarredChannels(c *gin.Context) {
+	userID := getUserID(c)
+
+	channels, err := h.service.ListStarredChannels(c.Request.Context(), userID, c.Query("workspace_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list starred channels"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"channels": channels})
+}
+
+func (h *ChannelHandler) ReorderStarredChannels(c *gin.Context) {
+	userID := getUserID(c)
+
+	var req models.ReorderStarredRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	channels, err := h.service.ReorderStarredChannels(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"channels": channels})
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Voice ──
+
+func (h *ChannelHandler) JoinVoiceChannel(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	var req models.UpdateVoiceStateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	state, err := h.service.JoinVoiceChannel(c.Request.Context(), channelID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+func (h *ChannelHandler) LeaveVoiceChannel(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	if err := h.service.LeaveVoiceChannel(c.Request.Context(), channelID, userID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ChannelHandler) UpdateVoiceState(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	var req models.UpdateVoiceStateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	state, err := h.service.UpdateVoiceState(c.Request.Context(), channelID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+func (h *ChannelHandler) ListVoiceParticipants(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	participants, err := h.service.ListVoiceParticipants(c.Request.Context(), channelID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"participants": participants})
+}
+
+func (h *ChannelHandler) VoiceHeartbeat(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	if err := h.service.VoiceHeartbeat(c.Request.Context(), channelID, userID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/quckapp/channel-service/internal/service"
+)
+
+// ── Voice History & Reports ──
+
+func (h *ChannelHandler) ListChannelVoiceSessions(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	filter, ok := parseVoiceSessionFilter(c)
+	if !ok {
+		return
+	}
+	filter.UserID = c.Query("user")
+
+	page, err := h.service.ListChannelVoiceSessions(c.Request.Context(), channelID, userID, filter, limit, offset)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *ChannelHandler) ListMyVoiceSessions(c *gin.Context) {
+	userID := getUserID(c)
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	filter, ok := parseVoiceSessionFilter(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.service.ListUserVoiceSessions(c.Request.Context(), userID, filter, limit, offset)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *ChannelHandler) GetVoiceUsageReport(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	filter, ok := parseVoiceSessionFilter(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetVoiceUsageReport(c.Request.Context(), channelID, userID, filter.From, filter.To)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// parseVoiceSessionFilter reads the from and to query parameters, writing
+// the error response itself when they are invalid.
+func parseVoiceSessionFilter(c *gin.Context) (*models.VoiceSessionFilter, bool) {
+	from, err := parseDateParam(c.Query("from"))
+	if err != nil {
+		handleError(c, service.ErrInvalidTimeRange)
+		return nil, false
+	}
+	to, err := parseDateParam(c.Query("to"))
+	if err != nil {
+		handleError(c, service.ErrInvalidTimeRange)
+		return nil, false
+	}
+	return &models.VoiceSessionFilter{From: from, To: to}, true
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Voice Moderation ──
+
+func (h *ChannelHandler) RaiseHand(c *gin.Context) {
+	h.setHandRaised(c, true)
+}
+
+func (h *ChannelHandler) LowerHand(c *gin.Context) {
+	h.setHandRaised(c, false)
+}
+
+func (h *ChannelHandler) setHandRaised(c *gin.Context, raised bool) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+
+	state, err := h.service.SetHandRaised(c.Request.Context(), channelID, userID, raised)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+func (h *ChannelHandler) ModerateVoiceParticipant(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	targetUserID := c.Param("userId")
+
+	var req models.ModerateVoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	state, err := h.service.ModerateVoiceParticipant(c.Request.Context(), channelID, targetUserID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+func (h *ChannelHandler) DisconnectVoiceParticipant(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	targetUserID := c.Param("userId")
+
+	var req models.DisconnectVoiceRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := h.service.DisconnectVoiceParticipant(c.Request.Context(), channelID, targetUserID, userID, &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ChannelHandler) MoveVoiceParticipant(c *gin.Context) {
+	userID := getUserID(c)
+	channelID := c.Param("id")
+	targetUserID := c.Param("userId")
+
+	var req models.MoveVoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	state, err := h.service.MoveVoiceParticipant(c.Request.Context(), channelID, targetUserID, userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
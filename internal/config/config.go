@@ -2,19 +2,37 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Port         string
-	Environment  string
-	DatabaseURL  string
-	RedisURL     string
-	KafkaBrokers []string
-	JWTSecret    string
-	ServiceName  string
+	Port               string
+	Environment        string
+	DatabaseURL        string
+	RedisURL           string
+	KafkaBrokers       []string
+	KafkaGroupID       string
+	KafkaMessageTopics []string
+	KafkaMemberTopics  []string
+	JWTSecret          string
+	ServiceName        string
+
+	AutoArchiveInterval    time.Duration
+	AutoArchiveWarningDays int
+
+	AckReminderInterval time.Duration
+	AckReminderDelay    time.Duration
+
+	UnfurlTimeout  time.Duration
+	UnfurlMaxBytes int64
+	UnfurlCacheTTL time.Duration
+
+	VoiceReaperInterval   time.Duration
+	VoiceHeartbeatTimeout time.Duration
 }
 
 func Load() (*Config, error) {
@@ -26,13 +44,29 @@ func Load() (*Config, error) {
 	}
 
 	return &Config{
-		Port:         getEnv("PORT", "3003"),
-		Environment:  getEnv("ENVIRONMENT", "development"),
-		DatabaseURL:  getEnv("DATABASE_URL", "root:password@tcp(localhost:3306)/quckapp_channels?parseTime=true"),
-		RedisURL:     getEnv("REDIS_URL", "localhost:6379"),
-		KafkaBrokers: strings.Split(kafkaBrokers, ","),
-		JWTSecret:    getEnv("JWT_SECRET", "your-secret-key"),
-		ServiceName:  "channel-service",
+		Port:               getEnv("PORT", "3003"),
+		Environment:        getEnv("ENVIRONMENT", "development"),
+		DatabaseURL:        getEnv("DATABASE_URL", "root:password@tcp(localhost:3306)/quckapp_channels?parseTime=true"),
+		RedisURL:           getEnv("REDIS_URL", "localhost:6379"),
+		KafkaBrokers:       strings.Split(kafkaBrokers, ","),
+		KafkaGroupID:       getEnv("KAFKA_GROUP_ID", "channel-service"),
+		KafkaMessageTopics: strings.Split(getEnv("KAFKA_MESSAGE_TOPICS", "message.created,message.deleted"), ","),
+		KafkaMemberTopics:  strings.Split(getEnv("KAFKA_MEMBER_TOPICS", "channel.member.joined,channel.member.left"), ","),
+		JWTSecret:          getEnv("JWT_SECRET", "your-secret-key"),
+		ServiceName:        "channel-service",
+
+		AutoArchiveInterval:    getEnvDuration("AUTO_ARCHIVE_INTERVAL", time.Hour),
+		AutoArchiveWarningDays: getEnvInt("AUTO_ARCHIVE_WARNING_DAYS", 3),
+
+		AckReminderInterval: getEnvDuration("ACK_REMINDER_INTERVAL", 15*time.Minute),
+		AckReminderDelay:    getEnvDuration("ACK_REMINDER_DELAY", 24*time.Hour),
+
+		UnfurlTimeout:  getEnvDuration("UNFURL_TIMEOUT", 5*time.Second),
+		UnfurlMaxBytes: int64(getEnvInt("UNFURL_MAX_BYTES", 1<<20)),
+		UnfurlCacheTTL: getEnvDuration("UNFURL_CACHE_TTL", 24*time.Hour),
+
+		VoiceReaperInterval:   getEnvDuration("VOICE_REAPER_INTERVAL", 30*time.Second),
+		VoiceHeartbeatTimeout: getEnvDuration("VOICE_HEARTBEAT_TIMEOUT", 90*time.Second),
 	}, nil
 }
 
@@ -42,3 +76,17 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -3,6 +3,8 @@ package db
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -59,3 +61,79 @@ func (p *KafkaProducer) Close() error {
 	}
 	return nil
 }
+
+// Failed messages are retried with exponential backoff between these bounds.
+const (
+	consumeRetryMin = 500 * time.Millisecond
+	consumeRetryMax = 30 * time.Second
+)
+
+type KafkaConsumer struct {
+	reader *kafka.Reader
+}
+
+func NewKafkaConsumer(brokers []string, groupID string, topics []string) (*KafkaConsumer, error) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     brokers,
+		GroupID:     groupID,
+		GroupTopics: topics,
+		MinBytes:    1,
+		MaxBytes:    10e6,
+	})
+	return &KafkaConsumer{reader: reader}, nil
+}
+
+// Consume hands every message to handler and commits its offset once the
+// handler succeeds. A failed message is retried with backoff until it
+// succeeds, so transient errors delay events rather than drop them; later
+// messages on the partition wait behind it. Handlers must be idempotent and
+// must return nil for messages they can never process, such as malformed
+// ones or ones the database rejects outright, or the partition stalls.
+// Consume returns nil when ctx is cancelled between messages, and an error
+// when it gives up on a message mid-retry or the reader fails; an
+// uncommitted message is redelivered on restart.
+func (c *KafkaConsumer) Consume(ctx context.Context, handler func(ctx context.Context, topic string, key, value []byte) error) error {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := handleWithRetry(ctx, msg, handler); err != nil {
+			return fmt.Errorf("abandoned %s[%d]@%d: %w", msg.Topic, msg.Partition, msg.Offset, err)
+		}
+		if err := c.reader.CommitMessages(ctx, msg); err != nil && ctx.Err() == nil {
+			return err
+		}
+	}
+}
+
+// handleWithRetry runs handler until it succeeds. When ctx is cancelled
+// first it returns the last handler error together with the cancellation.
+func handleWithRetry(ctx context.Context, msg kafka.Message, handler func(ctx context.Context, topic string, key, value []byte) error) error {
+	backoff := consumeRetryMin
+	for {
+		err := handler(ctx, msg.Topic, msg.Key, msg.Value)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > consumeRetryMax {
+			backoff = consumeRetryMax
+		}
+	}
+}
+
+func (c *KafkaConsumer) Close() error {
+	if c.reader != nil {
+		return c.reader.Close()
+	}
+	return nil
+}
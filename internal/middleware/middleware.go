@@ -75,8 +75,52 @@ func Auth(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
+		// Tokens are issued per workspace: role is the caller's role in
+		// workspace_id.
 		claims := token.Claims.(jwt.MapClaims)
 		c.Set("user_id", claims["sub"])
+		if role, ok := claims["role"].(string); ok {
+			c.Set("role", role)
+		}
+		if workspaceID, ok := claims["workspace_id"].(string); ok {
+			c.Set("workspace_id", workspaceID)
+		}
 		c.Next()
 	}
 }
+
+// RequireRole rejects requests whose token role is not one of roles.
+// It must run after Auth.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		c.Abort()
+	}
+}
+
+// RequireWorkspace rejects requests for any workspace other than the one
+// the token was issued for, taken from the :id path parameter. With roles,
+// the caller's role in that workspace must also be one of them. It must
+// run after Auth.
+func RequireWorkspace(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		workspaceID := c.GetString("workspace_id")
+		if workspaceID == "" || workspaceID != c.Param("id") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not a member of this workspace"})
+			c.Abort()
+			return
+		}
+		if len(roles) == 0 {
+			c.Next()
+			return
+		}
+		RequireRole(roles...)(c)
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -49,14 +50,30 @@ type CreateChannelRequest struct {
 }
 
 type UpdateChannelRequest struct {
-	Name        *string `json:"name"`
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
 	Description *string `json:"description"`
-	Topic       *string `json:"topic"`
+	Topic       *string `json:"topic" binding:"omitempty,max=500"`
 }
 
 type AddMemberRequest struct {
-	UserID string `json:"user_id" binding:"required"`
-	Role   string `json:"role" binding:"omitempty,oneof=owner admin member"`
+	UserID   string `json:"user_id" binding:"required"`
+	Role     string `json:"role" binding:"omitempty,oneof=owner admin member"`
+	Waitlist bool   `json:"waitlist"`
+}
+
+type BulkAddMembersRequest struct {
+	UserIDs  []string `json:"user_ids" binding:"required,min=1,max=1000,dive,required"`
+	Role     string   `json:"role" binding:"omitempty,oneof=admin member"`
+	Waitlist bool     `json:"waitlist"`
+}
+
+type CreateInviteRequest struct {
+	MaxUses   int        `json:"max_uses" binding:"omitempty,min=0,max=10000"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type RedeemInviteRequest struct {
+	Waitlist bool `json:"waitlist"`
 }
 
 // ── Polls ──
@@ -173,6 +190,9 @@ type ChannelTab struct {
 	CreatedBy string    `json:"created_by" db:"created_by"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// Previews holds cached link previews for "links" tabs.
+	Previews []*LinkPreview `json:"previews,omitempty" db:"-"`
 }
 
 type CreateTabRequest struct {
@@ -214,15 +234,849 @@ type ChannelTemplate struct {
 	UseCount        int       `json:"use_count" db:"use_count"`
 	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+
+	DefaultBookmarks   *string `json:"default_bookmarks" db:"default_bookmarks"`
+	DefaultPermissions *string `json:"default_permissions" db:"default_permissions"`
+	SourceChannelID    *string `json:"source_channel_id" db:"source_channel_id"`
+	// Version is the current version; earlier ones stay in
+	// ChannelTemplateVersion and can still be applied.
+	Version int `json:"version" db:"version"`
+	// WorkspaceID is the workspace of the channel the template was saved
+	// from. Visibility is private (creator only), workspace or global;
+	// IsPublic is kept in step with global for older clients.
+	WorkspaceID string `json:"workspace_id" db:"workspace_id"`
+	Visibility  string `json:"visibility" db:"visibility"`
+}
+
+// ChannelTemplateVersion is an immutable snapshot of a template's contents.
+type ChannelTemplateVersion struct {
+	ID                 string    `json:"id" db:"id"`
+	TemplateID         string    `json:"template_id" db:"template_id"`
+	Version            int       `json:"version" db:"version"`
+	ChannelType        string    `json:"channel_type" db:"channel_type"`
+	DefaultTopic       *string   `json:"default_topic" db:"default_topic"`
+	DefaultTabs        *string   `json:"default_tabs" db:"default_tabs"`
+	DefaultSettings    *string   `json:"default_settings" db:"default_settings"`
+	DefaultBookmarks   *string   `json:"default_bookmarks" db:"default_bookmarks"`
+	DefaultPermissions *string   `json:"default_permissions" db:"default_permissions"`
+	SourceChannelID    *string   `json:"source_channel_id" db:"source_channel_id"`
+	CreatedBy          string    `json:"created_by" db:"created_by"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
 }
 
 type CreateTemplateRequest struct {
 	Name        string  `json:"name" binding:"required,min=2,max=100"`
 	Description *string `json:"description"`
-	IsPublic    bool    `json:"is_public"`
+	// Visibility defaults to global when IsPublic is set and private
+	// otherwise.
+	Visibility string `json:"visibility" binding:"omitempty,oneof=private workspace global"`
+	IsPublic   bool   `json:"is_public"`
+}
+
+type UpdateTemplateRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=2,max=100"`
+	Description *string `json:"description"`
+	Visibility  *string `json:"visibility" binding:"omitempty,oneof=private workspace global"`
+}
+
+// TemplateFilter narrows the template catalog. WorkspaceID limits it to
+// templates usable in that workspace; Sort is "popular" or "recent".
+type TemplateFilter struct {
+	UserID      string
+	WorkspaceID string
+	Query       string
+	Sort        string
+}
+
+type TemplatePage struct {
+	Templates []*ChannelTemplate `json:"templates"`
+	Total     int                `json:"total"`
+	Limit     int                `json:"limit"`
+	Offset    int                `json:"offset"`
+}
+
+type CreateTemplateVersionRequest struct {
+	ChannelID string `json:"channel_id" binding:"required"`
+}
+
+// TemplateTab is one entry of a template's DefaultTabs JSON array.
+// DefaultSettings holds an UpdateChannelSettingsRequest as JSON.
+type TemplateTab struct {
+	Name    string  `json:"name"`
+	TabType string  `json:"tab_type"`
+	Config  *string `json:"config,omitempty"`
+}
+
+// TemplateBookmark is one entry of a template's DefaultBookmarks JSON array:
+// a message pinned in the source channel, bookmarked in new channels.
+type TemplateBookmark struct {
+	Title     string `json:"title"`
+	MessageID string `json:"message_id"`
+}
+
+// TemplatePermission is one entry of a template's DefaultPermissions JSON
+// array. Only role overrides are kept, since user overrides name people.
+type TemplatePermission struct {
+	PermissionType string `json:"permission_type"`
+	TargetType     string `json:"target_type"`
+	TargetID       string `json:"target_id"`
+	Allow          bool   `json:"allow"`
+	Deny           bool   `json:"deny"`
 }
 
 type ApplyTemplateRequest struct {
 	WorkspaceID string `json:"workspace_id" binding:"required"`
 	ChannelName string `json:"channel_name" binding:"required,min=1,max=100"`
+	// Version applies an earlier version; zero means the current one.
+	Version int `json:"version" binding:"omitempty,min=1"`
+}
+
+// ── Reactions ──
+
+type ChannelReaction struct {
+	ID        string    `json:"id" db:"id"`
+	ChannelID string    `json:"channel_id" db:"channel_id"`
+	MessageID string    `json:"message_id" db:"message_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	Emoji     string    `json:"emoji" db:"emoji"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type ReactionSummary struct {
+	Emoji string `json:"emoji" db:"emoji"`
+	Count int    `json:"count" db:"count"`
+}
+
+type MessageReactionSummary struct {
+	MessageID string `json:"message_id" db:"message_id"`
+	Emoji     string `json:"emoji" db:"emoji"`
+	Count     int    `json:"count" db:"count"`
+	Reacted   bool   `json:"reacted" db:"reacted"`
+}
+
+type ReactionUsers struct {
+	Emoji   string   `json:"emoji"`
+	Count   int      `json:"count"`
+	UserIDs []string `json:"user_ids"`
+}
+
+type ToggleReactionRequest struct {
+	Emoji string `json:"emoji" binding:"required,min=1,max=50"`
+}
+
+type ToggleReactionResult struct {
+	Added     bool              `json:"added"`
+	Reactions []ReactionSummary `json:"reactions"`
+}
+
+// ── Channel Settings ──
+
+type ChannelSetting struct {
+	ID                  string    `json:"id" db:"id"`
+	ChannelID           string    `json:"channel_id" db:"channel_id"`
+	SlowModeInterval    int       `json:"slow_mode_interval" db:"slow_mode_interval"`
+	MaxPins             int       `json:"max_pins" db:"max_pins"`
+	MaxBookmarks        int       `json:"max_bookmarks" db:"max_bookmarks"`
+	AllowThreads        bool      `json:"allow_threads" db:"allow_threads"`
+	AllowReactions      bool      `json:"allow_reactions" db:"allow_reactions"`
+	AllowInvites        bool      `json:"allow_invites" db:"allow_invites"`
+	AutoArchiveDays     int       `json:"auto_archive_days" db:"auto_archive_days"`
+	DefaultNotification string    `json:"default_notification" db:"default_notification"`
+	CustomEmoji         bool      `json:"custom_emoji" db:"custom_emoji"`
+	LinkPreviews        bool      `json:"link_previews" db:"link_previews"`
+	MemberLimit         int       `json:"member_limit" db:"member_limit"`
+	VoiceStageMode      bool      `json:"voice_stage_mode" db:"voice_stage_mode"`
+	CreatedAt           time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type UpdateChannelSettingsRequest struct {
+	SlowModeInterval    *int    `json:"slow_mode_interval" binding:"omitempty,min=0,max=21600"`
+	MaxPins             *int    `json:"max_pins" binding:"omitempty,min=1,max=500"`
+	MaxBookmarks        *int    `json:"max_bookmarks" binding:"omitempty,min=1,max=1000"`
+	AllowThreads        *bool   `json:"allow_threads"`
+	AllowReactions      *bool   `json:"allow_reactions"`
+	AllowInvites        *bool   `json:"allow_invites"`
+	AutoArchiveDays     *int    `json:"auto_archive_days" binding:"omitempty,min=0,max=3650"`
+	DefaultNotification *string `json:"default_notification" binding:"omitempty,oneof=all mentions none"`
+	CustomEmoji         *bool   `json:"custom_emoji"`
+	LinkPreviews        *bool   `json:"link_previews"`
+	MemberLimit         *int    `json:"member_limit" binding:"omitempty,min=0,max=100000"`
+	VoiceStageMode      *bool   `json:"voice_stage_mode"`
+}
+
+type SettingChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// ── Custom Emoji ──
+
+type CustomEmoji struct {
+	ID          string    `json:"id" db:"id"`
+	WorkspaceID string    `json:"workspace_id" db:"workspace_id"`
+	Shortcode   string    `json:"shortcode" db:"shortcode"`
+	ImageURL    string    `json:"image_url" db:"image_url"`
+	Aliases     []string  `json:"aliases" db:"-"`
+	CreatedBy   string    `json:"created_by" db:"created_by"`
+	UsageCount  int       `json:"usage_count" db:"usage_count"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CustomEmojiAlias struct {
+	EmojiID string `json:"emoji_id" db:"emoji_id"`
+	Alias   string `json:"alias" db:"alias"`
+}
+
+type EmojiUsage struct {
+	Emoji      string    `json:"emoji" db:"emoji"`
+	UseCount   int       `json:"use_count" db:"use_count"`
+	LastUsedAt time.Time `json:"last_used_at" db:"last_used_at"`
+}
+
+type CreateCustomEmojiRequest struct {
+	Shortcode string   `json:"shortcode" binding:"required,min=2,max=48"`
+	ImageURL  string   `json:"image_url" binding:"required,url,max=500"`
+	Aliases   []string `json:"aliases" binding:"max=10"`
+}
+
+// ── Read Receipts & Unread ──
+
+type ReadReceipt struct {
+	ID        string    `json:"id" db:"id"`
+	ChannelID string    `json:"channel_id" db:"channel_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	MessageID string    `json:"message_id" db:"message_id"`
+	ReadAt    time.Time `json:"read_at" db:"read_at"`
+}
+
+type MarkReadRequest struct {
+	MessageID string `json:"message_id" binding:"required"`
+}
+
+type MessageReader struct {
+	UserID     string    `json:"user_id" db:"user_id"`
+	LastReadAt time.Time `json:"last_read_at" db:"last_read_at"`
+}
+
+type MessageReaders struct {
+	MessageID string           `json:"message_id"`
+	ReadCount int              `json:"read_count"`
+	Readers   []*MessageReader `json:"readers"`
+}
+
+type ChannelUnread struct {
+	ChannelID    string     `json:"channel_id" db:"channel_id"`
+	UnreadCount  int        `json:"unread_count" db:"unread_count"`
+	MentionCount int        `json:"mention_count" db:"mention_count"`
+	LastReadAt   *time.Time `json:"last_read_at" db:"last_read_at"`
+}
+
+type UnreadSummary struct {
+	TotalUnread   int              `json:"total_unread"`
+	TotalMentions int              `json:"total_mentions"`
+	Channels      []*ChannelUnread `json:"channels"`
+}
+
+// ── Message Events ──
+
+// ChannelMessage is the slice of a message-service message the channel
+// service keeps to answer read-state questions without calling back.
+type ChannelMessage struct {
+	ID        string     `json:"id" db:"id"`
+	ChannelID string     `json:"channel_id" db:"channel_id"`
+	UserID    string     `json:"user_id" db:"user_id"`
+	ThreadID  *string    `json:"thread_id,omitempty" db:"thread_id"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+type ChannelCounters struct {
+	ChannelID      string     `json:"channel_id" db:"channel_id"`
+	MessageCount   int        `json:"message_count" db:"message_count"`
+	MemberCount    int        `json:"member_count" db:"member_count"`
+	LastMessageAt  *time.Time `json:"last_message_at" db:"last_message_at"`
+	LastActivityAt *time.Time `json:"last_activity_at" db:"last_activity_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+type MessageEvent struct {
+	Type      string     `json:"type"`
+	MessageID string     `json:"message_id"`
+	ChannelID string     `json:"channel_id"`
+	UserID    string     `json:"user_id"`
+	ThreadID  *string    `json:"thread_id,omitempty"`
+	Mentions  []string   `json:"mentions,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ── Analytics ──
+
+type ChannelStats struct {
+	MemberCount       int        `json:"member_count"`
+	PinCount          int        `json:"pin_count"`
+	ActiveMembersWeek int        `json:"active_members_week"`
+	MessageCount      int        `json:"message_count"`
+	MessagesWeek      int        `json:"messages_week"`
+	ThreadRepliesWeek int        `json:"thread_replies_week"`
+	ReactionsWeek     int        `json:"reactions_week"`
+	JoinsWeek         int        `json:"joins_week"`
+	LeavesWeek        int        `json:"leaves_week"`
+	LastActivityAt    *time.Time `json:"last_activity_at"`
+}
+
+type ChannelActivity struct {
+	Date         string `json:"date" db:"date"`
+	ActiveUsers  int    `json:"active_users" db:"active_users"`
+	MessageCount int    `json:"message_count" db:"message_count"`
+}
+
+type MostActiveMember struct {
+	UserID       string `json:"user_id" db:"user_id"`
+	MessageCount int    `json:"message_count" db:"message_count"`
+}
+
+type ActivityPoint struct {
+	PeriodStart   string `json:"period_start" db:"period_start"`
+	Messages      int    `json:"messages" db:"messages"`
+	ThreadReplies int    `json:"thread_replies" db:"thread_replies"`
+	Reactions     int    `json:"reactions" db:"reactions"`
+	Joins         int    `json:"joins" db:"joins"`
+	Leaves        int    `json:"leaves" db:"leaves"`
+	ActiveUsers   int    `json:"active_users" db:"active_users"`
+}
+
+type RetentionRow struct {
+	CohortStart string `db:"cohort_start"`
+	WeekOffset  int    `db:"week_offset"`
+	Users       int    `db:"users"`
+}
+
+type RetentionCohort struct {
+	CohortStart  string    `json:"cohort_start"`
+	Size         int       `json:"size"`
+	ActiveByWeek []int     `json:"active_by_week"`
+	Retention    []float64 `json:"retention"`
+}
+
+type MemberEvent struct {
+	EventID    string    `json:"event_id"`
+	Type       string    `json:"type"`
+	ChannelID  string    `json:"channel_id"`
+	UserID     string    `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type ChannelTypeCount struct {
+	Type     string `json:"type" db:"type"`
+	Total    int    `json:"total" db:"total"`
+	Archived int    `json:"archived" db:"archived"`
+}
+
+type WorkspaceGrowthPoint struct {
+	PeriodStart string `json:"period_start" db:"period_start"`
+	NewChannels int    `json:"new_channels" db:"new_channels"`
+	Joins       int    `json:"joins" db:"joins"`
+	Leaves      int    `json:"leaves" db:"leaves"`
+	Messages    int    `json:"messages" db:"messages"`
+}
+
+type DormantChannel struct {
+	ChannelID      string    `json:"channel_id" db:"channel_id"`
+	Name           string    `json:"name" db:"name"`
+	Type           string    `json:"type" db:"type"`
+	LastActivityAt time.Time `json:"last_activity_at" db:"last_activity_at"`
+}
+
+type ChannelJoinCount struct {
+	ChannelID string `json:"channel_id" db:"channel_id"`
+	Name      string `json:"name" db:"name"`
+	Joins     int    `json:"joins" db:"joins"`
+}
+
+type WorkspaceAnalytics struct {
+	WorkspaceID        string                  `json:"workspace_id"`
+	TotalChannels      int                     `json:"total_channels"`
+	ArchivedChannels   int                     `json:"archived_channels"`
+	ArchivedRatio      float64                 `json:"archived_ratio"`
+	ChannelsByType     []ChannelTypeCount      `json:"channels_by_type"`
+	AverageMembers     float64                 `json:"average_members"`
+	Growth             []*WorkspaceGrowthPoint `json:"growth"`
+	DormantDays        int                     `json:"dormant_days"`
+	DormantCount       int                     `json:"dormant_count"`
+	DormantChannels    []DormantChannel        `json:"dormant_channels"`
+	MostJoinedChannels []ChannelJoinCount      `json:"most_joined_channels"`
+}
+
+// ── Activity Log ──
+
+type ChannelActivityLog struct {
+	ID        string    `json:"id" db:"id"`
+	ChannelID string    `json:"channel_id" db:"channel_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	Action    string    `json:"action" db:"action"`
+	TargetID  *string   `json:"target_id,omitempty" db:"target_id"`
+	Details   *string   `json:"-" db:"details"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// DetailsJSON is Details as raw JSON for API responses.
+	DetailsJSON json.RawMessage `json:"details,omitempty" db:"-"`
+}
+
+// ActivityLogFilter narrows an audit query. Action may end in ".*" to
+// match every action with that prefix.
+type ActivityLogFilter struct {
+	ChannelID string
+	UserID    string
+	Action    string
+	TargetID  string
+	From      *time.Time
+	To        *time.Time
+	// Before is the (created_at, id) position to continue after.
+	BeforeTime *time.Time
+	BeforeID   string
+}
+
+type ActivityLogPage struct {
+	Entries    []*ChannelActivityLog `json:"entries"`
+	NextCursor *string               `json:"next_cursor"`
+}
+
+// ── Auto-Archive ──
+
+type AutoArchiveCandidate struct {
+	ChannelID       string    `json:"channel_id" db:"channel_id"`
+	WorkspaceID     string    `json:"workspace_id" db:"workspace_id"`
+	Name            string    `json:"name" db:"name"`
+	AutoArchiveDays int       `json:"auto_archive_days" db:"auto_archive_days"`
+	LastActivityAt  time.Time `json:"last_activity_at" db:"last_activity_at"`
+	// WarnedAt is when owners were warned about this idle period, if yet.
+	WarnedAt  *time.Time `json:"warned_at,omitempty" db:"warned_at"`
+	ArchiveAt time.Time  `json:"archive_at" db:"-"`
+	Due       bool       `json:"due" db:"-"`
+}
+
+type ChannelArchiveWarningEvent struct {
+	ChannelID      string    `json:"channel_id"`
+	WorkspaceID    string    `json:"workspace_id"`
+	Name           string    `json:"name"`
+	OwnerIDs       []string  `json:"owner_ids"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	ArchiveAt      time.Time `json:"archive_at"`
+}
+
+type ChannelArchivedEvent struct {
+	ChannelID   string    `json:"channel_id"`
+	WorkspaceID string    `json:"workspace_id"`
+	Reason      string    `json:"reason"`
+	ArchivedAt  time.Time `json:"archived_at"`
+}
+
+// ── Membership & Capacity ──
+
+type ChannelInvite struct {
+	ID        string     `json:"id" db:"id"`
+	ChannelID string     `json:"channel_id" db:"channel_id"`
+	CreatedBy string     `json:"created_by" db:"created_by"`
+	Code      string     `json:"code" db:"code"`
+	MaxUses   int        `json:"max_uses" db:"max_uses"`
+	UseCount  int        `json:"use_count" db:"use_count"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	IsActive  bool       `json:"is_active" db:"is_active"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type WaitlistEntry struct {
+	ChannelID string    `json:"channel_id" db:"channel_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	Role      string    `json:"role" db:"role"`
+	AddedBy   string    `json:"added_by" db:"added_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type MemberAddResult struct {
+	Added          []*ChannelMember `json:"added"`
+	AlreadyMembers []string         `json:"already_members"`
+	Waitlisted     []string         `json:"waitlisted"`
+	Rejected       []string         `json:"rejected"`
+}
+
+type JoinResult struct {
+	Member           *ChannelMember `json:"member,omitempty"`
+	Waitlisted       bool           `json:"waitlisted"`
+	WaitlistPosition int            `json:"waitlist_position,omitempty"`
+}
+
+// ── Starred Channels ──
+
+type StarredChannel struct {
+	ID        string    `json:"id" db:"id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	ChannelID string    `json:"channel_id" db:"channel_id"`
+	Position  int       `json:"position" db:"position"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type StarredChannelDetails struct {
+	Channel
+	Position  int       `json:"position" db:"position"`
+	StarredAt time.Time `json:"starred_at" db:"starred_at"`
+}
+
+type ReorderStarredRequest struct {
+	ChannelIDs []string `json:"channel_ids" binding:"required,dive,required"`
+}
+
+// ── Sidebar Sections ──
+
+type ChannelSection struct {
+	ID          string    `json:"id" db:"id"`
+	WorkspaceID string    `json:"workspace_id" db:"workspace_id"`
+	UserID      string    `json:"user_id" db:"user_id"`
+	Name        string    `json:"name" db:"name"`
+	Position    int       `json:"position" db:"position"`
+	IsCollapsed bool      `json:"is_collapsed" db:"is_collapsed"`
+	ChannelIDs  *string   `json:"-" db:"channel_ids"`
+	Channels    []string  `json:"channel_ids" db:"-"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CreateSectionRequest struct {
+	WorkspaceID string `json:"workspace_id" binding:"required"`
+	Name        string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type UpdateSectionRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	IsCollapsed *bool   `json:"is_collapsed"`
+}
+
+type ReorderSectionsRequest struct {
+	WorkspaceID string   `json:"workspace_id" binding:"required"`
+	SectionIDs  []string `json:"section_ids" binding:"required,dive,required"`
+}
+
+// MoveChannelRequest places a channel in a section. An empty SectionID
+// moves it back to the uncategorized list.
+type MoveChannelRequest struct {
+	SectionID string `json:"section_id"`
+	Position  *int   `json:"position" binding:"omitempty,min=0"`
+}
+
+type SidebarChannel struct {
+	*Channel
+	UnreadCount  int `json:"unread_count"`
+	MentionCount int `json:"mention_count"`
+}
+
+type SidebarSection struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Position    int               `json:"position"`
+	IsCollapsed bool              `json:"is_collapsed"`
+	Channels    []*SidebarChannel `json:"channels"`
+}
+
+type Sidebar struct {
+	WorkspaceID   string            `json:"workspace_id"`
+	Starred       []*SidebarChannel `json:"starred"`
+	Sections      []*SidebarSection `json:"sections"`
+	Uncategorized []*SidebarChannel `json:"uncategorized"`
+	TotalUnread   int               `json:"total_unread"`
+	TotalMentions int               `json:"total_mentions"`
+}
+
+// ── Bookmarks ──
+
+type ChannelBookmark struct {
+	ID         string    `json:"id" db:"id"`
+	ChannelID  string    `json:"channel_id" db:"channel_id"`
+	UserID     string    `json:"user_id" db:"user_id"`
+	Title      string    `json:"title" db:"title"`
+	URL        *string   `json:"url,omitempty" db:"url"`
+	EntityType *string   `json:"entity_type,omitempty" db:"entity_type"`
+	EntityID   *string   `json:"entity_id,omitempty" db:"entity_id"`
+	Position   int       `json:"position" db:"position"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// BookmarkWithEntity is a bookmark with its target's current title. For
+// entity bookmarks whose target was deleted, EntityExists is false.
+type BookmarkWithEntity struct {
+	ChannelBookmark
+	EntityTitle  *string      `json:"entity_title,omitempty" db:"entity_title"`
+	EntityExists *bool        `json:"entity_exists,omitempty" db:"entity_exists"`
+	Preview      *LinkPreview `json:"preview,omitempty" db:"-"`
+}
+
+type CreateBookmarkRequest struct {
+	Title      string  `json:"title" binding:"max=255"`
+	URL        *string `json:"url" binding:"omitempty,max=2000"`
+	EntityType *string `json:"entity_type" binding:"omitempty,oneof=thread poll announcement message"`
+	EntityID   *string `json:"entity_id"`
+}
+
+type UpdateBookmarkRequest struct {
+	Title *string `json:"title" binding:"omitempty,min=1,max=255"`
+	URL   *string `json:"url" binding:"omitempty,max=2000"`
+}
+
+type ReorderBookmarksRequest struct {
+	BookmarkIDs []string `json:"bookmark_ids" binding:"required,dive,required"`
+}
+
+// ── Link Previews ──
+
+// LinkPreview is the OpenGraph/oEmbed metadata unfurled from a URL.
+type LinkPreview struct {
+	URL         string    `json:"url"`
+	Type        string    `json:"type,omitempty"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	SiteName    string    `json:"site_name,omitempty"`
+	ImageURL    string    `json:"image_url,omitempty"`
+	AuthorName  string    `json:"author_name,omitempty"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+// ── Announcements ──
+
+type ChannelAnnouncement struct {
+	ID                string     `json:"id" db:"id"`
+	ChannelID         string     `json:"channel_id" db:"channel_id"`
+	Title             string     `json:"title" db:"title"`
+	Content           string     `json:"content" db:"content"`
+	Priority          string     `json:"priority" db:"priority"` // low, normal, high, urgent
+	AuthorID          string     `json:"author_id" db:"author_id"`
+	IsPinned          bool       `json:"is_pinned" db:"is_pinned"`
+	RequiresAck       bool       `json:"requires_ack" db:"requires_ack"`
+	AckReminderSentAt *time.Time `json:"-" db:"ack_reminder_sent_at"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+type CreateAnnouncementRequest struct {
+	Title       string     `json:"title" binding:"required,min=1,max=255"`
+	Content     string     `json:"content" binding:"required,min=1,max=10000"`
+	Priority    string     `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	IsPinned    bool       `json:"is_pinned"`
+	RequiresAck bool       `json:"requires_ack"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type UpdateAnnouncementRequest struct {
+	Title       *string    `json:"title" binding:"omitempty,min=1,max=255"`
+	Content     *string    `json:"content" binding:"omitempty,min=1,max=10000"`
+	Priority    *string    `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	RequiresAck *bool      `json:"requires_ack"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type AnnouncementAck struct {
+	AnnouncementID string    `json:"announcement_id" db:"announcement_id"`
+	UserID         string    `json:"user_id" db:"user_id"`
+	AcknowledgedAt time.Time `json:"acknowledged_at" db:"acknowledged_at"`
+}
+
+// AnnouncementAckReport compares acknowledgements against the channel's
+// current members. Outstanding excludes the author.
+type AnnouncementAckReport struct {
+	AnnouncementID    string             `json:"announcement_id"`
+	RequiresAck       bool               `json:"requires_ack"`
+	AcknowledgedCount int                `json:"acknowledged_count"`
+	OutstandingCount  int                `json:"outstanding_count"`
+	Acknowledged      []*AnnouncementAck `json:"acknowledged"`
+	Outstanding       []string           `json:"outstanding"`
+}
+
+type AnnouncementEvent struct {
+	AnnouncementID string    `json:"announcement_id"`
+	ChannelID      string    `json:"channel_id"`
+	Title          string    `json:"title"`
+	Priority       string    `json:"priority"`
+	AuthorID       string    `json:"author_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// NotificationEvent asks the notification service to notify RecipientIDs.
+type NotificationEvent struct {
+	Type         string    `json:"type"`
+	Priority     string    `json:"priority"`
+	ChannelID    string    `json:"channel_id"`
+	EntityID     string    `json:"entity_id"`
+	Title        string    `json:"title"`
+	Body         string    `json:"body"`
+	ActorID      string    `json:"actor_id"`
+	RecipientIDs []string  `json:"recipient_ids"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ── Topic History ──
+
+type TopicHistory struct {
+	ID        string    `json:"id" db:"id"`
+	ChannelID string    `json:"channel_id" db:"channel_id"`
+	OldTopic  *string   `json:"old_topic" db:"old_topic"`
+	NewTopic  *string   `json:"new_topic" db:"new_topic"`
+	ChangedBy string    `json:"changed_by" db:"changed_by"`
+	ChangedAt time.Time `json:"changed_at" db:"changed_at"`
+}
+
+type TopicHistoryPage struct {
+	History []*TopicHistory `json:"history"`
+	Total   int             `json:"total"`
+	Limit   int             `json:"limit"`
+	Offset  int             `json:"offset"`
+}
+
+// RevertTopicRequest undoes the given topic change, restoring its old
+// topic. Without a history ID the most recent change is undone.
+type RevertTopicRequest struct {
+	HistoryID *string `json:"history_id"`
+}
+
+// ── Moderation ──
+
+type ModerationEntry struct {
+	ID        string     `json:"id" db:"id"`
+	ChannelID string     `json:"channel_id" db:"channel_id"`
+	UserID    string     `json:"user_id" db:"user_id"`
+	Action    string     `json:"action" db:"action"`
+	ActorID   string     `json:"actor_id" db:"actor_id"`
+	Reason    *string    `json:"reason,omitempty" db:"reason"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ── Audit Export ──
+
+// AuditRecord is one exported row from either the activity log
+// (Source "activity") or the moderation log (Source "moderation").
+type AuditRecord struct {
+	Source        string     `json:"source" db:"source"`
+	ID            string     `json:"id" db:"id"`
+	ChannelID     string     `json:"channel_id" db:"channel_id"`
+	ActorID       string     `json:"actor_id" db:"actor_id"`
+	Action        string     `json:"action" db:"action"`
+	TargetID      *string    `json:"target_id,omitempty" db:"target_id"`
+	SubjectUserID *string    `json:"subject_user_id,omitempty" db:"subject_user_id"`
+	Reason        *string    `json:"reason,omitempty" db:"reason"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	Details       *string    `json:"-" db:"details"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+
+	// DetailsJSON is Details as raw JSON for API responses.
+	DetailsJSON json.RawMessage `json:"details,omitempty" db:"-"`
+}
+
+// ── Voice ──
+
+type VoiceChannelState struct {
+	ID             string     `json:"id" db:"id"`
+	ChannelID      string     `json:"channel_id" db:"channel_id"`
+	UserID         string     `json:"user_id" db:"user_id"`
+	IsMuted        bool       `json:"is_muted" db:"is_muted"`
+	IsDeafened     bool       `json:"is_deafened" db:"is_deafened"`
+	IsScreenShare  bool       `json:"is_screen_share" db:"is_screen_share"`
+	IsVideoOn      bool       `json:"is_video_on" db:"is_video_on"`
+	JoinedAt       time.Time  `json:"joined_at" db:"joined_at"`
+	DisconnectedAt *time.Time `json:"disconnected_at,omitempty" db:"disconnected_at"`
+
+	// Set by channel admins and independent of the user's own mute and
+	// deafen flags; clients treat the user as muted if either is set.
+	IsServerMuted    bool `json:"is_server_muted" db:"is_server_muted"`
+	IsServerDeafened bool `json:"is_server_deafened" db:"is_server_deafened"`
+	// In stage mode only speakers may unmute; audience members raise hands.
+	IsSpeaker    bool       `json:"is_speaker" db:"is_speaker"`
+	HandRaisedAt *time.Time `json:"hand_raised_at,omitempty" db:"hand_raised_at"`
+}
+
+// UpdateVoiceStateRequest also sets the initial state when joining.
+type UpdateVoiceStateRequest struct {
+	IsMuted       *bool `json:"is_muted"`
+	IsDeafened    *bool `json:"is_deafened"`
+	IsScreenShare *bool `json:"is_screen_share"`
+	IsVideoOn     *bool `json:"is_video_on"`
+}
+
+type VoiceStateEvent struct {
+	Type       string             `json:"type"`
+	ChannelID  string             `json:"channel_id"`
+	UserID     string             `json:"user_id"`
+	ActorID    string             `json:"actor_id,omitempty"`
+	State      *VoiceChannelState `json:"state,omitempty"`
+	OccurredAt time.Time          `json:"occurred_at"`
+}
+
+// ModerateVoiceRequest changes a participant's server-side voice state.
+type ModerateVoiceRequest struct {
+	ServerMuted    *bool   `json:"server_muted"`
+	ServerDeafened *bool   `json:"server_deafened"`
+	Speaker        *bool   `json:"speaker"`
+	Reason         *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+type DisconnectVoiceRequest struct {
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+type MoveVoiceRequest struct {
+	ChannelID string  `json:"channel_id" binding:"required"`
+	Reason    *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+// VoiceSessionFilter narrows a voice history query to sessions that started
+// in [From, To).
+type VoiceSessionFilter struct {
+	ChannelID string
+	UserID    string
+	From      time.Time
+	To        time.Time
+}
+
+type VoiceSessionPage struct {
+	Sessions []*VoiceChannelState `json:"sessions"`
+	Total    int                  `json:"total"`
+	Limit    int                  `json:"limit"`
+	Offset   int                  `json:"offset"`
+}
+
+type VoiceUsageDay struct {
+	Day          string  `json:"day" db:"day"`
+	Minutes      float64 `json:"minutes" db:"minutes"`
+	Participants int     `json:"participants" db:"participants"`
+}
+
+// VoiceUsageReport summarizes a channel's voice usage. Sessions still in
+// progress count up to now.
+type VoiceUsageReport struct {
+	ChannelID             string           `json:"channel_id"`
+	From                  time.Time        `json:"from"`
+	To                    time.Time        `json:"to"`
+	Sessions              int              `json:"sessions" db:"sessions"`
+	UniqueParticipants    int              `json:"unique_participants" db:"unique_participants"`
+	TotalMinutes          float64          `json:"total_minutes" db:"total_minutes"`
+	AverageSessionMinutes float64          `json:"average_session_minutes" db:"average_session_minutes"`
+	PeakConcurrent        int              `json:"peak_concurrent"`
+	PeakAt                *time.Time       `json:"peak_at,omitempty"`
+	Daily                 []*VoiceUsageDay `json:"daily"`
+}
+
+// ── Permissions ──
+
+type ChannelPermission struct {
+	ID             string    `json:"id" db:"id"`
+	ChannelID      string    `json:"channel_id" db:"channel_id"`
+	PermissionType string    `json:"permission_type" db:"permission_type"`
+	TargetType     string    `json:"target_type" db:"target_type"`
+	TargetID       string    `json:"target_id" db:"target_id"`
+	Allow          bool      `json:"allow" db:"allow"`
+	Deny           bool      `json:"deny" db:"deny"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 }
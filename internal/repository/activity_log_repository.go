@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/quckapp/channel-service/internal/models"
@@ -39,3 +41,82 @@ func (r *ActivityLogRepository) ListByAction(ctx context.Context, channelID, act
 	err := r.db.SelectContext(ctx, &entries, `SELECT * FROM channel_activity_log WHERE channel_id = ? AND action = ? ORDER BY created_at DESC LIMIT ? OFFSET ?`, channelID, action, limit, offset)
 	return entries, err
 }
+
+// Search lists entries matching filter, newest first, returning at most
+// limit entries.
+func (r *ActivityLogRepository) Search(ctx context.Context, filter *models.ActivityLogFilter, limit int) ([]*models.ChannelActivityLog, error) {
+	conditions := []string{"channel_id = ?"}
+	args := []interface{}{filter.ChannelID}
+
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if prefix, ok := strings.CutSuffix(filter.Action, ".*"); ok {
+		conditions = append(conditions, "action LIKE ?")
+		args = append(args, escapeLike(prefix)+".%")
+	} else if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.TargetID != "" {
+		conditions = append(conditions, "target_id = ?")
+		args = append(args, filter.TargetID)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *filter.To)
+	}
+	if filter.BeforeTime != nil {
+		conditions = append(conditions, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, *filter.BeforeTime, *filter.BeforeTime, filter.BeforeID)
+	}
+
+	query := `SELECT * FROM channel_activity_log WHERE ` + strings.Join(conditions, " AND ") +
+		` ORDER BY created_at DESC, id DESC LIMIT ?`
+	args = append(args, limit)
+
+	var entries []*models.ChannelActivityLog
+	err := r.db.SelectContext(ctx, &entries, query, args...)
+	return entries, err
+}
+
+// StreamByWorkspace calls fn for every entry in the workspace's channels,
+// deleted channels included, created in [from, to), oldest first. Rows are
+// read one at a time so large exports stay out of memory.
+func (r *ActivityLogRepository) StreamByWorkspace(ctx context.Context, workspaceID string, from, to time.Time, fn func(*models.AuditRecord) error) error {
+	query := `SELECT 'activity' AS source, a.id, a.channel_id, a.user_id AS actor_id, a.action, a.target_id,
+			NULL AS subject_user_id, NULL AS reason, NULL AS expires_at, a.details, a.created_at
+		FROM channel_activity_log a
+		INNER JOIN channels c ON c.id = a.channel_id
+		WHERE c.workspace_id = ? AND a.created_at >= ? AND a.created_at < ?
+		ORDER BY a.created_at, a.id`
+	return streamAuditRecords(ctx, r.db, fn, query, workspaceID, from, to)
+}
+
+func streamAuditRecords(ctx context.Context, db *sqlx.DB, fn func(*models.AuditRecord) error, query string, args ...interface{}) error {
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record models.AuditRecord
+		if err := rows.StructScan(&record); err != nil {
+			return err
+		}
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
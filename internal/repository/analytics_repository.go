@@ -2,11 +2,37 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/quckapp/channel-service/internal/models"
 )
 
+// Rollup metrics. Values double as column names in channel_daily_stats.
+const (
+	MetricMessages      = "messages"
+	MetricThreadReplies = "thread_replies"
+	MetricReactions     = "reactions"
+	MetricJoins         = "joins"
+	MetricLeaves        = "leaves"
+)
+
+// periodExpression returns the SQL bucketing column into a period label for
+// granularity. Weeks start on Monday.
+func periodExpression(granularity, column string) (string, error) {
+	switch granularity {
+	case "day":
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d')", column), nil
+	case "week":
+		return fmt.Sprintf("DATE_FORMAT(DATE_SUB(DATE(%[1]s), INTERVAL WEEKDAY(%[1]s) DAY), '%%Y-%%m-%%d')", column), nil
+	case "month":
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-01')", column), nil
+	}
+	return "", fmt.Errorf("unknown granularity %q", granularity)
+}
+
 type AnalyticsRepository struct {
 	db *sqlx.DB
 }
@@ -15,6 +41,82 @@ func NewAnalyticsRepository(db *sqlx.DB) *AnalyticsRepository {
 	return &AnalyticsRepository{db: db}
 }
 
+// RecordActivity adds one to the channel's daily rollup for metric and, for
+// messages, thread replies and reactions, to the acting user's daily row.
+func (r *AnalyticsRepository) RecordActivity(ctx context.Context, channelID, userID, metric string, at time.Time) error {
+	return recordActivity(ctx, r.db, channelID, userID, metric, at)
+}
+
+func recordActivity(ctx context.Context, exec sqlx.ExecerContext, channelID, userID, metric string, at time.Time) error {
+	switch metric {
+	case MetricMessages, MetricThreadReplies, MetricReactions, MetricJoins, MetricLeaves:
+	default:
+		return fmt.Errorf("unknown analytics metric %q", metric)
+	}
+
+	day := at.Format("2006-01-02")
+	query := fmt.Sprintf(`INSERT INTO channel_daily_stats (channel_id, day, %[1]s) VALUES (?, ?, 1)
+		ON DUPLICATE KEY UPDATE %[1]s = %[1]s + 1`, metric)
+	if _, err := exec.ExecContext(ctx, query, channelID, day); err != nil {
+		return err
+	}
+
+	userColumn := ""
+	switch metric {
+	case MetricMessages, MetricThreadReplies:
+		userColumn = "messages"
+	case MetricReactions:
+		userColumn = "reactions"
+	}
+	if userColumn == "" {
+		return nil
+	}
+
+	counterQuery := `INSERT INTO channel_counters (channel_id, last_activity_at, updated_at) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE last_activity_at = GREATEST(COALESCE(last_activity_at, VALUES(last_activity_at)), VALUES(last_activity_at)),
+			updated_at = VALUES(updated_at)`
+	if _, err := exec.ExecContext(ctx, counterQuery, channelID, at, time.Now()); err != nil {
+		return err
+	}
+	if userID == "" {
+		return nil
+	}
+
+	query = fmt.Sprintf(`INSERT INTO channel_daily_user_activity (channel_id, user_id, day, %[1]s) VALUES (?, ?, ?, 1)
+		ON DUPLICATE KEY UPDATE %[1]s = %[1]s + 1`, userColumn)
+	_, err := exec.ExecContext(ctx, query, channelID, userID, day)
+	return err
+}
+
+// RecordMembershipEvent counts a join or leave exactly once per event ID.
+func (r *AnalyticsRepository) RecordMembershipEvent(ctx context.Context, eventID, channelID, metric string, at time.Time) (bool, error) {
+	if metric != MetricJoins && metric != MetricLeaves {
+		return false, fmt.Errorf("unknown membership metric %q", metric)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `INSERT IGNORE INTO processed_events (event_id, processed_at) VALUES (?, ?)`, eventID, time.Now())
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO channel_daily_stats (channel_id, day, %[1]s) VALUES (?, ?, 1)
+		ON DUPLICATE KEY UPDATE %[1]s = %[1]s + 1`, metric)
+	if _, err := tx.ExecContext(ctx, query, channelID, at.Format("2006-01-02")); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
 func (r *AnalyticsRepository) GetChannelStats(ctx context.Context, channelID string) (*models.ChannelStats, error) {
 	stats := &models.ChannelStats{}
 
@@ -26,41 +128,219 @@ func (r *AnalyticsRepository) GetChannelStats(ctx context.Context, channelID str
 	pinQuery := `SELECT COUNT(*) FROM channel_pins WHERE channel_id = ?`
 	r.db.GetContext(ctx, &stats.PinCount, pinQuery, channelID)
 
-	// Active members this week (members who updated last_read_at in last 7 days)
-	activeQuery := `SELECT COUNT(*) FROM channel_members WHERE channel_id = ? AND last_read_at >= DATE_SUB(NOW(), INTERVAL 7 DAY)`
+	// Members who posted or reacted in the last 7 days
+	activeQuery := `SELECT COUNT(DISTINCT user_id) FROM channel_daily_user_activity WHERE channel_id = ? AND day >= DATE_SUB(CURDATE(), INTERVAL 6 DAY)`
 	r.db.GetContext(ctx, &stats.ActiveMembersWeek, activeQuery, channelID)
 
+	// Lifetime message count and last activity from the event counters
+	counterQuery := `SELECT message_count, last_activity_at FROM channel_counters WHERE channel_id = ?`
+	r.db.QueryRowxContext(ctx, counterQuery, channelID).Scan(&stats.MessageCount, &stats.LastActivityAt)
+
+	// Weekly totals from the daily rollups
+	weekQuery := `SELECT COALESCE(SUM(messages), 0), COALESCE(SUM(thread_replies), 0), COALESCE(SUM(reactions), 0), COALESCE(SUM(joins), 0), COALESCE(SUM(leaves), 0)
+		FROM channel_daily_stats WHERE channel_id = ? AND day >= DATE_SUB(CURDATE(), INTERVAL 6 DAY)`
+	r.db.QueryRowxContext(ctx, weekQuery, channelID).Scan(&stats.MessagesWeek, &stats.ThreadRepliesWeek, &stats.ReactionsWeek, &stats.JoinsWeek, &stats.LeavesWeek)
+
 	return stats, nil
 }
 
 func (r *AnalyticsRepository) GetDailyActivity(ctx context.Context, channelID string, days int) ([]models.ChannelActivity, error) {
 	var activities []models.ChannelActivity
-	query := `SELECT DATE(last_read_at) as date, COUNT(DISTINCT user_id) as active_users, 0 as message_count
-		FROM channel_members
-		WHERE channel_id = ? AND last_read_at >= DATE_SUB(NOW(), INTERVAL ? DAY)
-		GROUP BY DATE(last_read_at)
-		ORDER BY date DESC`
+	query := `SELECT DATE_FORMAT(s.day, '%Y-%m-%d') as date,
+			(SELECT COUNT(*) FROM channel_daily_user_activity a WHERE a.channel_id = s.channel_id AND a.day = s.day) as active_users,
+			s.messages + s.thread_replies as message_count
+		FROM channel_daily_stats s
+		WHERE s.channel_id = ? AND s.day >= DATE_SUB(CURDATE(), INTERVAL ? DAY)
+		ORDER BY s.day DESC`
 	err := r.db.SelectContext(ctx, &activities, query, channelID, days)
 	return activities, err
 }
 
-func (r *AnalyticsRepository) GetMostActiveMembers(ctx context.Context, channelID string, limit int) ([]models.MostActiveMember, error) {
+func (r *AnalyticsRepository) GetTimeSeries(ctx context.Context, channelID, granularity string, from, to time.Time) ([]*models.ActivityPoint, error) {
+	period, err := periodExpression(granularity, "day")
+	if err != nil {
+		return nil, err
+	}
+
+	var points []*models.ActivityPoint
+	query := fmt.Sprintf(`SELECT %[1]s AS period_start,
+			SUM(messages) AS messages, SUM(thread_replies) AS thread_replies, SUM(reactions) AS reactions,
+			SUM(joins) AS joins, SUM(leaves) AS leaves
+		FROM channel_daily_stats
+		WHERE channel_id = ? AND day BETWEEN ? AND ?
+		GROUP BY period_start ORDER BY period_start`, period)
+	if err := r.db.SelectContext(ctx, &points, query, channelID, from.Format("2006-01-02"), to.Format("2006-01-02")); err != nil {
+		return nil, err
+	}
+
+	var active []struct {
+		PeriodStart string `db:"period_start"`
+		ActiveUsers int    `db:"active_users"`
+	}
+	query = fmt.Sprintf(`SELECT %[1]s AS period_start, COUNT(DISTINCT user_id) AS active_users
+		FROM channel_daily_user_activity
+		WHERE channel_id = ? AND day BETWEEN ? AND ?
+		GROUP BY period_start`, period)
+	if err := r.db.SelectContext(ctx, &active, query, channelID, from.Format("2006-01-02"), to.Format("2006-01-02")); err != nil {
+		return nil, err
+	}
+
+	byPeriod := make(map[string]int, len(active))
+	for _, a := range active {
+		byPeriod[a.PeriodStart] = a.ActiveUsers
+	}
+	for _, p := range points {
+		p.ActiveUsers = byPeriod[p.PeriodStart]
+	}
+
+	return points, nil
+}
+
+// GetRetention groups users by the week of their first activity in the
+// channel and counts how many of them were active in each following week.
+func (r *AnalyticsRepository) GetRetention(ctx context.Context, channelID string, since time.Time) ([]models.RetentionRow, error) {
+	var rows []models.RetentionRow
+	query := `SELECT DATE_FORMAT(f.cohort, '%Y-%m-%d') AS cohort_start,
+			FLOOR(DATEDIFF(a.week_start, f.cohort) / 7) AS week_offset,
+			COUNT(DISTINCT a.user_id) AS users
+		FROM (
+			SELECT user_id, MIN(DATE_SUB(day, INTERVAL WEEKDAY(day) DAY)) AS cohort
+			FROM channel_daily_user_activity WHERE channel_id = ? GROUP BY user_id
+		) f
+		INNER JOIN (
+			SELECT DISTINCT user_id, DATE_SUB(day, INTERVAL WEEKDAY(day) DAY) AS week_start
+			FROM channel_daily_user_activity WHERE channel_id = ?
+		) a ON a.user_id = f.user_id
+		WHERE f.cohort >= ?
+		GROUP BY f.cohort, week_offset
+		ORDER BY f.cohort, week_offset`
+	err := r.db.SelectContext(ctx, &rows, query, channelID, channelID, since.Format("2006-01-02"))
+	return rows, err
+}
+
+func (r *AnalyticsRepository) GetMostActiveMembers(ctx context.Context, channelID string, since time.Time, limit int) ([]models.MostActiveMember, error) {
 	var members []models.MostActiveMember
-	// Using reaction count as activity proxy since we don't have messages table
-	query := `SELECT user_id, COUNT(*) as message_count FROM channel_reactions
-		WHERE channel_id = ? GROUP BY user_id ORDER BY message_count DESC LIMIT ?`
-	err := r.db.SelectContext(ctx, &members, query, channelID, limit)
+	query := `SELECT user_id, SUM(messages) as message_count FROM channel_daily_user_activity
+		WHERE channel_id = ? AND day >= ? GROUP BY user_id HAVING message_count > 0
+		ORDER BY message_count DESC LIMIT ?`
+	err := r.db.SelectContext(ctx, &members, query, channelID, since.Format("2006-01-02"), limit)
 	return members, err
 }
 
 func (r *AnalyticsRepository) GetTopChannels(ctx context.Context, workspaceID string, limit int) ([]*models.Channel, error) {
 	var channels []*models.Channel
 	query := `SELECT c.* FROM channels c
-		LEFT JOIN channel_members cm ON c.id = cm.channel_id
+		LEFT JOIN channel_counters cc ON cc.channel_id = c.id
 		WHERE c.workspace_id = ? AND c.deleted_at IS NULL
-		GROUP BY c.id
-		ORDER BY COUNT(cm.id) DESC
+		ORDER BY COALESCE(cc.member_count, 0) DESC
 		LIMIT ?`
 	err := r.db.SelectContext(ctx, &channels, query, workspaceID, limit)
 	return channels, err
 }
+
+// ── Workspace ──
+
+func (r *AnalyticsRepository) CountChannelsByType(ctx context.Context, workspaceID string) ([]models.ChannelTypeCount, error) {
+	var counts []models.ChannelTypeCount
+	query := `SELECT type, COUNT(*) as total, COALESCE(SUM(is_archived), 0) as archived
+		FROM channels WHERE workspace_id = ? AND deleted_at IS NULL
+		GROUP BY type ORDER BY total DESC`
+	err := r.db.SelectContext(ctx, &counts, query, workspaceID)
+	return counts, err
+}
+
+// GetAverageMembers averages the member counts of active channels.
+func (r *AnalyticsRepository) GetAverageMembers(ctx context.Context, workspaceID string) (float64, error) {
+	var avg float64
+	query := `SELECT COALESCE(AVG(COALESCE(cc.member_count, 0)), 0) FROM channels c
+		LEFT JOIN channel_counters cc ON cc.channel_id = c.id
+		WHERE c.workspace_id = ? AND c.deleted_at IS NULL AND c.is_archived = FALSE`
+	err := r.db.GetContext(ctx, &avg, query, workspaceID)
+	return avg, err
+}
+
+func (r *AnalyticsRepository) GetWorkspaceGrowth(ctx context.Context, workspaceID, granularity string, since time.Time) ([]*models.WorkspaceGrowthPoint, error) {
+	statsPeriod, err := periodExpression(granularity, "s.day")
+	if err != nil {
+		return nil, err
+	}
+	createdPeriod, err := periodExpression(granularity, "created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	var points []*models.WorkspaceGrowthPoint
+	query := fmt.Sprintf(`SELECT %s AS period_start, 0 AS new_channels,
+			SUM(s.joins) AS joins, SUM(s.leaves) AS leaves, SUM(s.messages + s.thread_replies) AS messages
+		FROM channel_daily_stats s
+		INNER JOIN channels c ON c.id = s.channel_id
+		WHERE c.workspace_id = ? AND s.day >= ?
+		GROUP BY period_start ORDER BY period_start`, statsPeriod)
+	if err := r.db.SelectContext(ctx, &points, query, workspaceID, since.Format("2006-01-02")); err != nil {
+		return nil, err
+	}
+
+	var created []struct {
+		PeriodStart string `db:"period_start"`
+		NewChannels int    `db:"new_channels"`
+	}
+	query = fmt.Sprintf(`SELECT %s AS period_start, COUNT(*) AS new_channels
+		FROM channels WHERE workspace_id = ? AND created_at >= ?
+		GROUP BY period_start`, createdPeriod)
+	if err := r.db.SelectContext(ctx, &created, query, workspaceID, since.Format("2006-01-02")); err != nil {
+		return nil, err
+	}
+
+	byPeriod := make(map[string]*models.WorkspaceGrowthPoint, len(points))
+	for _, p := range points {
+		byPeriod[p.PeriodStart] = p
+	}
+	for _, c := range created {
+		if p, ok := byPeriod[c.PeriodStart]; ok {
+			p.NewChannels = c.NewChannels
+			continue
+		}
+		points = append(points, &models.WorkspaceGrowthPoint{PeriodStart: c.PeriodStart, NewChannels: c.NewChannels})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].PeriodStart < points[j].PeriodStart })
+
+	return points, nil
+}
+
+// ListDormantChannels returns unarchived channels with no activity since
+// before, falling back to the creation time for channels never used.
+func (r *AnalyticsRepository) ListDormantChannels(ctx context.Context, workspaceID string, before time.Time, limit int) ([]models.DormantChannel, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM channels c
+		LEFT JOIN channel_counters cc ON c.id = cc.channel_id
+		WHERE c.workspace_id = ? AND c.deleted_at IS NULL AND c.is_archived = FALSE
+			AND COALESCE(cc.last_activity_at, c.created_at) < ?`
+	if err := r.db.GetContext(ctx, &total, countQuery, workspaceID, before); err != nil {
+		return nil, 0, err
+	}
+
+	var channels []models.DormantChannel
+	query := `SELECT c.id as channel_id, c.name, c.type, COALESCE(cc.last_activity_at, c.created_at) as last_activity_at
+		FROM channels c
+		LEFT JOIN channel_counters cc ON c.id = cc.channel_id
+		WHERE c.workspace_id = ? AND c.deleted_at IS NULL AND c.is_archived = FALSE
+			AND COALESCE(cc.last_activity_at, c.created_at) < ?
+		ORDER BY last_activity_at ASC
+		LIMIT ?`
+	err := r.db.SelectContext(ctx, &channels, query, workspaceID, before, limit)
+	return channels, total, err
+}
+
+func (r *AnalyticsRepository) GetMostJoinedChannels(ctx context.Context, workspaceID string, since time.Time, limit int) ([]models.ChannelJoinCount, error) {
+	var channels []models.ChannelJoinCount
+	query := `SELECT c.id as channel_id, c.name, SUM(s.joins) as joins
+		FROM channel_daily_stats s
+		INNER JOIN channels c ON c.id = s.channel_id
+		WHERE c.workspace_id = ? AND c.deleted_at IS NULL AND s.day >= ?
+		GROUP BY c.id, c.name
+		HAVING joins > 0
+		ORDER BY joins DESC
+		LIMIT ?`
+	err := r.db.SelectContext(ctx, &channels, query, workspaceID, since.Format("2006-01-02"), limit)
+	return channels, err
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/quckapp/channel-service/internal/models"
@@ -17,11 +18,11 @@ func NewAnnouncementRepository(db *sqlx.DB) *AnnouncementRepository {
 }
 
 func (r *AnnouncementRepository) Create(ctx context.Context, ann *models.ChannelAnnouncement) error {
-	query := `INSERT INTO channel_announcements (id, channel_id, title, content, priority, author_id, is_pinned, expires_at, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO channel_announcements (id, channel_id, title, content, priority, author_id, is_pinned, requires_ack, expires_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	_, err := r.db.ExecContext(ctx, query,
 		ann.ID, ann.ChannelID, ann.Title, ann.Content, ann.Priority,
-		ann.AuthorID, ann.IsPinned, ann.ExpiresAt, ann.CreatedAt, ann.UpdatedAt)
+		ann.AuthorID, ann.IsPinned, ann.RequiresAck, ann.ExpiresAt, ann.CreatedAt, ann.UpdatedAt)
 	return err
 }
 
@@ -42,9 +43,18 @@ func (r *AnnouncementRepository) ListByChannel(ctx context.Context, channelID st
 	return anns, err
 }
 
+// ListActiveByChannel lists announcements that have not expired as of now.
+func (r *AnnouncementRepository) ListActiveByChannel(ctx context.Context, channelID string, now time.Time) ([]*models.ChannelAnnouncement, error) {
+	var anns []*models.ChannelAnnouncement
+	query := `SELECT * FROM channel_announcements WHERE channel_id = ? AND (expires_at IS NULL OR expires_at > ?)
+		ORDER BY is_pinned DESC, created_at DESC`
+	err := r.db.SelectContext(ctx, &anns, query, channelID, now)
+	return anns, err
+}
+
 func (r *AnnouncementRepository) Update(ctx context.Context, ann *models.ChannelAnnouncement) error {
-	query := `UPDATE channel_announcements SET title = ?, content = ?, priority = ?, expires_at = ?, updated_at = NOW() WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, ann.Title, ann.Content, ann.Priority, ann.ExpiresAt, ann.ID)
+	query := `UPDATE channel_announcements SET title = ?, content = ?, priority = ?, requires_ack = ?, expires_at = ?, updated_at = NOW() WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, ann.Title, ann.Content, ann.Priority, ann.RequiresAck, ann.ExpiresAt, ann.ID)
 	return err
 }
 
@@ -59,3 +69,60 @@ func (r *AnnouncementRepository) TogglePin(ctx context.Context, id string, pinne
 	_, err := r.db.ExecContext(ctx, query, pinned, id)
 	return err
 }
+
+// ── Acknowledgements ──
+
+// Acknowledge records the user's acknowledgement. Repeat calls keep the
+// first acknowledgement time, which is returned.
+func (r *AnnouncementRepository) Acknowledge(ctx context.Context, announcementID, userID string, at time.Time) (*models.AnnouncementAck, error) {
+	query := `INSERT IGNORE INTO channel_announcement_acks (announcement_id, user_id, acknowledged_at) VALUES (?, ?, ?)`
+	if _, err := r.db.ExecContext(ctx, query, announcementID, userID, at); err != nil {
+		return nil, err
+	}
+
+	var ack models.AnnouncementAck
+	err := r.db.GetContext(ctx, &ack, `SELECT * FROM channel_announcement_acks WHERE announcement_id = ? AND user_id = ?`, announcementID, userID)
+	return &ack, err
+}
+
+func (r *AnnouncementRepository) ListAcks(ctx context.Context, announcementID string) ([]*models.AnnouncementAck, error) {
+	var acks []*models.AnnouncementAck
+	query := `SELECT * FROM channel_announcement_acks WHERE announcement_id = ? ORDER BY acknowledged_at`
+	err := r.db.SelectContext(ctx, &acks, query, announcementID)
+	return acks, err
+}
+
+// ListOutstanding returns current channel members, other than the author,
+// who have not acknowledged the announcement.
+func (r *AnnouncementRepository) ListOutstanding(ctx context.Context, ann *models.ChannelAnnouncement) ([]string, error) {
+	var userIDs []string
+	query := `SELECT m.user_id FROM channel_members m
+		LEFT JOIN channel_announcement_acks a ON a.announcement_id = ? AND a.user_id = m.user_id
+		WHERE m.channel_id = ? AND m.user_id != ? AND a.user_id IS NULL
+		ORDER BY m.joined_at`
+	err := r.db.SelectContext(ctx, &userIDs, query, ann.ID, ann.ChannelID, ann.AuthorID)
+	return userIDs, err
+}
+
+// ListDueAckReminders lists unexpired announcements requiring
+// acknowledgement that were posted before cutoff and not yet reminded.
+func (r *AnnouncementRepository) ListDueAckReminders(ctx context.Context, cutoff, now time.Time, limit int) ([]*models.ChannelAnnouncement, error) {
+	var anns []*models.ChannelAnnouncement
+	query := `SELECT * FROM channel_announcements
+		WHERE requires_ack = TRUE AND ack_reminder_sent_at IS NULL AND created_at <= ?
+			AND (expires_at IS NULL OR expires_at > ?)
+		ORDER BY created_at LIMIT ?`
+	err := r.db.SelectContext(ctx, &anns, query, cutoff, now, limit)
+	return anns, err
+}
+
+// ClaimAckReminder marks the reminder as sent. It returns false if another
+// worker already claimed it.
+func (r *AnnouncementRepository) ClaimAckReminder(ctx context.Context, announcementID string, at time.Time) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `UPDATE channel_announcements SET ack_reminder_sent_at = ?, updated_at = updated_at WHERE id = ? AND ack_reminder_sent_at IS NULL`, at, announcementID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/quckapp/channel-service/internal/models"
@@ -17,9 +18,13 @@ func NewBookmarkRepository(db *sqlx.DB) *BookmarkRepository {
 }
 
 func (r *BookmarkRepository) Create(ctx context.Context, bookmark *models.ChannelBookmark) error {
+	return insertBookmark(ctx, r.db, bookmark)
+}
+
+func insertBookmark(ctx context.Context, exec sqlx.ExecerContext, bookmark *models.ChannelBookmark) error {
 	query := `INSERT INTO channel_bookmarks (id, channel_id, user_id, title, url, entity_type, entity_id, position, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := exec.ExecContext(ctx, query,
 		bookmark.ID, bookmark.ChannelID, bookmark.UserID, bookmark.Title,
 		bookmark.URL, bookmark.EntityType, bookmark.EntityID, bookmark.Position,
 		bookmark.CreatedAt, bookmark.UpdatedAt)
@@ -71,3 +76,59 @@ func (r *BookmarkRepository) CountByUser(ctx context.Context, channelID, userID
 	err := r.db.GetContext(ctx, &count, query, channelID, userID)
 	return count, err
 }
+
+// ListWithEntities returns the user's bookmarks in a channel, resolving
+// thread, poll and announcement bookmarks to their current titles. Message
+// bookmarks have no title here and keep their own.
+func (r *BookmarkRepository) ListWithEntities(ctx context.Context, channelID, userID string) ([]*models.BookmarkWithEntity, error) {
+	var bookmarks []*models.BookmarkWithEntity
+	query := `SELECT b.*,
+			CASE b.entity_type WHEN 'thread' THEN t.title WHEN 'poll' THEN p.question WHEN 'announcement' THEN a.title END as entity_title,
+			CASE WHEN b.entity_type IS NULL THEN NULL
+				ELSE COALESCE(t.id, p.id, a.id, m.id) IS NOT NULL END as entity_exists
+		FROM channel_bookmarks b
+		LEFT JOIN channel_threads t ON b.entity_type = 'thread' AND t.id = b.entity_id
+		LEFT JOIN channel_polls p ON b.entity_type = 'poll' AND p.id = b.entity_id
+		LEFT JOIN channel_announcements a ON b.entity_type = 'announcement' AND a.id = b.entity_id
+		LEFT JOIN channel_messages m ON b.entity_type = 'message' AND m.id = b.entity_id AND m.deleted_at IS NULL
+		WHERE b.channel_id = ? AND b.user_id = ?
+		ORDER BY b.position ASC`
+	err := r.db.SelectContext(ctx, &bookmarks, query, channelID, userID)
+	return bookmarks, err
+}
+
+var bookmarkEntityQueries = map[string]string{
+	"thread":       `SELECT channel_id, COALESCE(title, '') as title FROM channel_threads WHERE id = ?`,
+	"poll":         `SELECT channel_id, question as title FROM channel_polls WHERE id = ?`,
+	"announcement": `SELECT channel_id, title FROM channel_announcements WHERE id = ?`,
+	"message":      `SELECT channel_id, '' as title FROM channel_messages WHERE id = ? AND deleted_at IS NULL`,
+}
+
+// GetEntity looks up the channel and current title of a bookmarkable
+// entity. It returns found=false if the entity does not exist.
+func (r *BookmarkRepository) GetEntity(ctx context.Context, entityType, entityID string) (channelID, title string, found bool, err error) {
+	query, ok := bookmarkEntityQueries[entityType]
+	if !ok {
+		return "", "", false, fmt.Errorf("unknown bookmark entity type %q", entityType)
+	}
+
+	var row struct {
+		ChannelID string `db:"channel_id"`
+		Title     string `db:"title"`
+	}
+	err = r.db.GetContext(ctx, &row, query, entityID)
+	if err == sql.ErrNoRows {
+		return "", "", false, nil
+	}
+	if err != nil {
+		return "", "", false, err
+	}
+	return row.ChannelID, row.Title, true, nil
+}
+
+// Reorder assigns positions following bookmarkIDs, which must list exactly
+// the user's bookmarks in the channel. It returns false if the sets differ.
+func (r *BookmarkRepository) Reorder(ctx context.Context, channelID, userID string, bookmarkIDs []string) (bool, error) {
+	scope := map[string]string{"channel_id": channelID, "user_id": userID}
+	return reorderPositions(ctx, r.db, "channel_bookmarks", "id", scope, bookmarkIDs)
+}
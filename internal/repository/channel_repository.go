@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"strings"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"github.com/quckapp/channel-service/internal/models"
 )
@@ -17,10 +20,49 @@ func NewChannelRepository(db *sqlx.DB) *ChannelRepository {
 	return &ChannelRepository{db: db}
 }
 
+// MySQL server error numbers the service reacts to.
+const (
+	mysqlBadNull         = 1048
+	mysqlDuplicateEntry  = 1062
+	mysqlTruncatedValue  = 1292
+	mysqlIncorrectValue  = 1366
+	mysqlDataTooLong     = 1406
+	mysqlNoReferencedRow = 1452
+)
+
+// IsDuplicateKey reports whether err is a duplicate-entry error on the named
+// unique key.
+func IsDuplicateKey(err error, key string) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlDuplicateEntry {
+		return false
+	}
+	return strings.Contains(mysqlErr.Message, key+"'")
+}
+
+// IsPermanent reports whether err is a MySQL error that retrying the same
+// statement cannot fix, such as a row referencing a channel that does not
+// exist or a value the column rejects.
+func IsPermanent(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	switch mysqlErr.Number {
+	case mysqlBadNull, mysqlTruncatedValue, mysqlIncorrectValue, mysqlDataTooLong, mysqlNoReferencedRow:
+		return true
+	}
+	return false
+}
+
 func (r *ChannelRepository) Create(ctx context.Context, ch *models.Channel) error {
+	return insertChannel(ctx, r.db, ch)
+}
+
+func insertChannel(ctx context.Context, exec sqlx.ExecerContext, ch *models.Channel) error {
 	query := `INSERT INTO channels (id, workspace_id, name, type, description, topic, icon_url, is_archived, created_by, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query, ch.ID, ch.WorkspaceID, ch.Name, ch.Type, ch.Description, ch.Topic, ch.IconURL, ch.IsArchived, ch.CreatedBy, ch.CreatedAt, ch.UpdatedAt)
+	_, err := exec.ExecContext(ctx, query, ch.ID, ch.WorkspaceID, ch.Name, ch.Type, ch.Description, ch.Topic, ch.IconURL, ch.IsArchived, ch.CreatedBy, ch.CreatedAt, ch.UpdatedAt)
 	return err
 }
 
@@ -34,10 +76,54 @@ func (r *ChannelRepository) GetByID(ctx context.Context, id string) (*models.Cha
 	return &ch, err
 }
 
-func (r *ChannelRepository) Update(ctx context.Context, ch *models.Channel) error {
+// Update saves the channel's editable fields. If the topic changed, entry
+// (with ID, ChangedBy and ChangedAt set by the caller) is completed with the
+// old and new topics and recorded in the same transaction. It reports
+// whether the topic changed, and returns sql.ErrNoRows if the channel does
+// not exist.
+func (r *ChannelRepository) Update(ctx context.Context, ch *models.Channel, entry *models.TopicHistory) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var oldTopic *string
+	if err := tx.GetContext(ctx, &oldTopic, `SELECT topic FROM channels WHERE id = ? AND deleted_at IS NULL FOR UPDATE`, ch.ID); err != nil {
+		return false, err
+	}
+
 	query := `UPDATE channels SET name = ?, description = ?, topic = ?, icon_url = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`
-	_, err := r.db.ExecContext(ctx, query, ch.Name, ch.Description, ch.Topic, ch.IconURL, time.Now(), ch.ID)
-	return err
+	if _, err := tx.ExecContext(ctx, query, ch.Name, ch.Description, ch.Topic, ch.IconURL, time.Now(), ch.ID); err != nil {
+		return false, err
+	}
+
+	changed := !sameTopic(oldTopic, ch.Topic)
+	if changed {
+		entry.ChannelID = ch.ID
+		entry.OldTopic = oldTopic
+		entry.NewTopic = ch.Topic
+		if err := insertTopicHistory(ctx, tx, entry); err != nil {
+			return false, err
+		}
+	}
+
+	return changed, tx.Commit()
+}
+
+// NameTaken reports whether another channel in the workspace uses name.
+func (r *ChannelRepository) NameTaken(ctx context.Context, workspaceID, name, excludeID string) (bool, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM channels WHERE workspace_id = ? AND name = ? AND id != ?`
+	err := r.db.GetContext(ctx, &count, query, workspaceID, name, excludeID)
+	return count > 0, err
+}
+
+func sameTopic(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
 
 func (r *ChannelRepository) Delete(ctx context.Context, id string) error {
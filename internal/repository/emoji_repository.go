@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+type EmojiRepository struct {
+	db *sqlx.DB
+}
+
+func NewEmojiRepository(db *sqlx.DB) *EmojiRepository {
+	return &EmojiRepository{db: db}
+}
+
+// Unique keys that reject a custom emoji name already in use.
+var emojiNameKeys = []string{"unique_custom_emoji", "unique_custom_emoji_alias", "unique_custom_emoji_name"}
+
+// IsEmojiNameTaken reports whether err from Create is a name collision.
+func IsEmojiNameTaken(err error) bool {
+	for _, key := range emojiNameKeys {
+		if IsDuplicateKey(err, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *EmojiRepository) Create(ctx context.Context, emoji *models.CustomEmoji) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO custom_emoji (id, workspace_id, shortcode, image_url, created_by, usage_count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	if _, err := tx.ExecContext(ctx, query, emoji.ID, emoji.WorkspaceID, emoji.Shortcode, emoji.ImageURL, emoji.CreatedBy, emoji.UsageCount, emoji.CreatedAt, emoji.UpdatedAt); err != nil {
+		return err
+	}
+
+	for _, alias := range emoji.Aliases {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO custom_emoji_aliases (emoji_id, workspace_id, alias) VALUES (?, ?, ?)`,
+			emoji.ID, emoji.WorkspaceID, alias); err != nil {
+			return err
+		}
+	}
+
+	// custom_emoji_names holds the shortcode and aliases together, so its
+	// unique key stops a name being both one emoji's shortcode and
+	// another's alias.
+	for _, name := range append([]string{emoji.Shortcode}, emoji.Aliases...) {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO custom_emoji_names (emoji_id, workspace_id, name) VALUES (?, ?, ?)`,
+			emoji.ID, emoji.WorkspaceID, name); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *EmojiRepository) GetByID(ctx context.Context, id string) (*models.CustomEmoji, error) {
+	var emoji models.CustomEmoji
+	err := r.db.GetContext(ctx, &emoji, `SELECT * FROM custom_emoji WHERE id = ?`, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &emoji, err
+}
+
+// GetByName resolves a shortcode or one of its aliases to the registry entry.
+func (r *EmojiRepository) GetByName(ctx context.Context, workspaceID, name string) (*models.CustomEmoji, error) {
+	var emoji models.CustomEmoji
+	query := `SELECT e.* FROM custom_emoji e WHERE e.workspace_id = ? AND e.shortcode = ?
+		UNION
+		SELECT e.* FROM custom_emoji e
+		INNER JOIN custom_emoji_aliases a ON a.emoji_id = e.id
+		WHERE a.workspace_id = ? AND a.alias = ?
+		LIMIT 1`
+	err := r.db.GetContext(ctx, &emoji, query, workspaceID, name, workspaceID, name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &emoji, err
+}
+
+func (r *EmojiRepository) ListByWorkspace(ctx context.Context, workspaceID string) ([]*models.CustomEmoji, error) {
+	var emojis []*models.CustomEmoji
+	if err := r.db.SelectContext(ctx, &emojis, `SELECT * FROM custom_emoji WHERE workspace_id = ? ORDER BY shortcode`, workspaceID); err != nil {
+		return nil, err
+	}
+
+	var aliases []models.CustomEmojiAlias
+	if err := r.db.SelectContext(ctx, &aliases, `SELECT emoji_id, alias FROM custom_emoji_aliases WHERE workspace_id = ? ORDER BY alias`, workspaceID); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*models.CustomEmoji, len(emojis))
+	for _, emoji := range emojis {
+		emoji.Aliases = []string{}
+		byID[emoji.ID] = emoji
+	}
+	for _, alias := range aliases {
+		if emoji, ok := byID[alias.EmojiID]; ok {
+			emoji.Aliases = append(emoji.Aliases, alias.Alias)
+		}
+	}
+
+	return emojis, nil
+}
+
+func (r *EmojiRepository) ListAliases(ctx context.Context, emojiID string) ([]string, error) {
+	aliases := []string{}
+	err := r.db.SelectContext(ctx, &aliases, `SELECT alias FROM custom_emoji_aliases WHERE emoji_id = ? ORDER BY alias`, emojiID)
+	return aliases, err
+}
+
+// NamesTaken reports whether any of the names is already used as a shortcode
+// or alias in the workspace.
+func (r *EmojiRepository) NamesTaken(ctx context.Context, workspaceID string, names []string) (bool, error) {
+	var count int
+	query, args, err := sqlx.In(`SELECT COUNT(*) FROM custom_emoji_names WHERE workspace_id = ? AND name IN (?)`,
+		workspaceID, names)
+	if err != nil {
+		return false, err
+	}
+	err = r.db.GetContext(ctx, &count, r.db.Rebind(query), args...)
+	return count > 0, err
+}
+
+func (r *EmojiRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM custom_emoji WHERE id = ?`, id)
+	return err
+}
+
+func (r *EmojiRepository) IncrementUsageCount(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE custom_emoji SET usage_count = usage_count + 1 WHERE id = ?`, id)
+	return err
+}
+
+func (r *EmojiRepository) RecordUsage(ctx context.Context, workspaceID, userID, emoji string) error {
+	query := `INSERT INTO emoji_usage (workspace_id, user_id, emoji, use_count, last_used_at) VALUES (?, ?, ?, 1, ?)
+		ON DUPLICATE KEY UPDATE use_count = use_count + 1, last_used_at = VALUES(last_used_at)`
+	_, err := r.db.ExecContext(ctx, query, workspaceID, userID, emoji, time.Now())
+	return err
+}
+
+func (r *EmojiRepository) ListFrequent(ctx context.Context, workspaceID, userID string, limit int) ([]*models.EmojiUsage, error) {
+	var usage []*models.EmojiUsage
+	query := `SELECT emoji, use_count, last_used_at FROM emoji_usage WHERE workspace_id = ? AND user_id = ?
+		ORDER BY use_count DESC, last_used_at DESC LIMIT ?`
+	err := r.db.SelectContext(ctx, &usage, query, workspaceID, userID, limit)
+	return usage, err
+}
@@ -69,3 +69,51 @@ func (r *InviteRepository) DeactivateExpired(ctx context.Context) error {
 	_, err := r.db.ExecContext(ctx, query)
 	return err
 }
+
+// RedeemOutcome describes what happened when an invite was redeemed.
+type RedeemOutcome int
+
+const (
+	RedeemJoined RedeemOutcome = iota
+	RedeemAlreadyMember
+	RedeemChannelFull
+	RedeemInviteExhausted
+)
+
+// Redeem adds member within limit and consumes one use of the invite in a
+// single transaction. A use is only consumed when the member is actually
+// added; expired, deactivated or used-up invites add nobody.
+func (r *InviteRepository) Redeem(ctx context.Context, invite *models.ChannelInvite, member *models.ChannelMember, limit int) (RedeemOutcome, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	_, existing, full, err := addMembersWithinLimit(ctx, tx, invite.ChannelID, []*models.ChannelMember{member}, limit)
+	if err != nil {
+		return 0, err
+	}
+	if len(existing) > 0 {
+		return RedeemAlreadyMember, nil
+	}
+	if len(full) > 0 {
+		return RedeemChannelFull, nil
+	}
+
+	res, err := tx.ExecContext(ctx, `UPDATE channel_invites SET use_count = use_count + 1
+		WHERE id = ? AND is_active = TRUE AND (max_uses = 0 OR use_count < max_uses)
+			AND (expires_at IS NULL OR expires_at > NOW())`, invite.ID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return RedeemInviteExhausted, nil
+	}
+
+	return RedeemJoined, tx.Commit()
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/quckapp/channel-service/internal/models"
@@ -17,9 +18,33 @@ func NewMemberRepository(db *sqlx.DB) *MemberRepository {
 }
 
 func (r *MemberRepository) Create(ctx context.Context, m *models.ChannelMember) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `INSERT INTO channel_members (id, channel_id, user_id, role, notifications, joined_at)
 		VALUES (?, ?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query, m.ID, m.ChannelID, m.UserID, m.Role, m.Notifications, m.JoinedAt)
+	if _, err := tx.ExecContext(ctx, query, m.ID, m.ChannelID, m.UserID, m.Role, m.Notifications, m.JoinedAt); err != nil {
+		return err
+	}
+	if err := adjustMemberCount(ctx, tx, m.ChannelID, 1); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// adjustMemberCount moves channel_counters.member_count by delta in the
+// transaction that changed the membership, so analytics can read the count
+// without scanning channel_members.
+func adjustMemberCount(ctx context.Context, exec sqlx.ExecerContext, channelID string, delta int) error {
+	if delta == 0 {
+		return nil
+	}
+	query := `INSERT INTO channel_counters (channel_id, member_count, updated_at) VALUES (?, GREATEST(?, 0), ?)
+		ON DUPLICATE KEY UPDATE member_count = GREATEST(member_count + ?, 0), updated_at = VALUES(updated_at)`
+	_, err := exec.ExecContext(ctx, query, channelID, delta, time.Now(), delta)
 	return err
 }
 
@@ -41,8 +66,7 @@ func (r *MemberRepository) ListByChannel(ctx context.Context, channelID string)
 }
 
 func (r *MemberRepository) Remove(ctx context.Context, channelID, userID string) error {
-	query := `DELETE FROM channel_members WHERE channel_id = ? AND user_id = ?`
-	_, err := r.db.ExecContext(ctx, query, channelID, userID)
+	_, err := r.RemoveMember(ctx, channelID, userID)
 	return err
 }
 
@@ -64,6 +88,27 @@ func (r *MemberRepository) UpdateLastRead(ctx context.Context, channelID, userID
 	return err
 }
 
+// UpdateLastReadAt moves the read position forward only, so a late or
+// out-of-order mark-read cannot make already-read messages unread again.
+func (r *MemberRepository) UpdateLastReadAt(ctx context.Context, channelID, userID string, readAt time.Time) error {
+	query := `UPDATE channel_members SET last_read_at = GREATEST(COALESCE(last_read_at, ?), ?) WHERE channel_id = ? AND user_id = ?`
+	_, err := r.db.ExecContext(ctx, query, readAt, readAt, channelID, userID)
+	return err
+}
+
+func (r *MemberRepository) ListReadSince(ctx context.Context, channelID string, since time.Time) ([]*models.MessageReader, error) {
+	var readers []*models.MessageReader
+	query := `SELECT user_id, last_read_at FROM channel_members WHERE channel_id = ? AND last_read_at >= ? ORDER BY last_read_at`
+	err := r.db.SelectContext(ctx, &readers, query, channelID, since)
+	return readers, err
+}
+
+func (r *MemberRepository) ListUserIDs(ctx context.Context, channelID string) ([]string, error) {
+	var userIDs []string
+	err := r.db.SelectContext(ctx, &userIDs, `SELECT user_id FROM channel_members WHERE channel_id = ?`, channelID)
+	return userIDs, err
+}
+
 func (r *MemberRepository) IsMember(ctx context.Context, channelID, userID string) (bool, error) {
 	var count int
 	query := `SELECT COUNT(*) FROM channel_members WHERE channel_id = ? AND user_id = ?`
@@ -77,3 +122,159 @@ func (r *MemberRepository) GetRole(ctx context.Context, channelID, userID string
 	err := r.db.GetContext(ctx, &role, query, channelID, userID)
 	return role, err
 }
+
+func (r *MemberRepository) ListUserIDsByRole(ctx context.Context, channelID string, roles ...string) ([]string, error) {
+	query, args, err := sqlx.In(`SELECT user_id FROM channel_members WHERE channel_id = ? AND role IN (?)`, channelID, roles)
+	if err != nil {
+		return nil, err
+	}
+	var userIDs []string
+	err = r.db.SelectContext(ctx, &userIDs, r.db.Rebind(query), args...)
+	return userIDs, err
+}
+
+// ── Capacity ──
+
+// addMembersWithinLimit inserts members in order until the channel holds
+// limit members (0 means unlimited). The channel row is locked for the rest
+// of tx, so concurrent adds to the same channel serialize and cannot
+// overshoot the limit. It returns the members inserted, the user IDs that
+// were already members and the user IDs that did not fit.
+func addMembersWithinLimit(ctx context.Context, tx *sqlx.Tx, channelID string, members []*models.ChannelMember, limit int) (added []*models.ChannelMember, existing, full []string, err error) {
+	var locked string
+	if err := tx.GetContext(ctx, &locked, `SELECT id FROM channels WHERE id = ? FOR UPDATE`, channelID); err != nil {
+		return nil, nil, nil, err
+	}
+
+	var count int
+	if err := tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM channel_members WHERE channel_id = ?`, channelID); err != nil {
+		return nil, nil, nil, err
+	}
+
+	for _, m := range members {
+		var exists int
+		if err := tx.GetContext(ctx, &exists, `SELECT COUNT(*) FROM channel_members WHERE channel_id = ? AND user_id = ?`, channelID, m.UserID); err != nil {
+			return nil, nil, nil, err
+		}
+		if exists > 0 {
+			existing = append(existing, m.UserID)
+			continue
+		}
+		if limit > 0 && count >= limit {
+			full = append(full, m.UserID)
+			continue
+		}
+
+		query := `INSERT INTO channel_members (id, channel_id, user_id, role, notifications, joined_at)
+			VALUES (?, ?, ?, ?, ?, ?)`
+		if _, err := tx.ExecContext(ctx, query, m.ID, channelID, m.UserID, m.Role, m.Notifications, m.JoinedAt); err != nil {
+			return nil, nil, nil, err
+		}
+		added = append(added, m)
+		count++
+	}
+
+	if err := adjustMemberCount(ctx, tx, channelID, len(added)); err != nil {
+		return nil, nil, nil, err
+	}
+	return added, existing, full, nil
+}
+
+// AddWithinLimit atomically adds members subject to the channel's member limit.
+func (r *MemberRepository) AddWithinLimit(ctx context.Context, channelID string, members []*models.ChannelMember, limit int) ([]*models.ChannelMember, []string, []string, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer tx.Rollback()
+
+	added, existing, full, err := addMembersWithinLimit(ctx, tx, channelID, members, limit)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return added, existing, full, tx.Commit()
+}
+
+// RemoveMember deletes the membership and reports whether one existed.
+func (r *MemberRepository) RemoveMember(ctx context.Context, channelID, userID string) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM channel_members WHERE channel_id = ? AND user_id = ?`, channelID, userID)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	if err := adjustMemberCount(ctx, tx, channelID, -1); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// ── Waitlist ──
+
+func (r *MemberRepository) AddToWaitlist(ctx context.Context, entry *models.WaitlistEntry) error {
+	query := `INSERT IGNORE INTO channel_waitlist (channel_id, user_id, role, added_by, created_at) VALUES (?, ?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query, entry.ChannelID, entry.UserID, entry.Role, entry.AddedBy, entry.CreatedAt)
+	return err
+}
+
+// GetWaitlistPosition returns the 1-based position of userID, or 0 if the
+// user is not waitlisted.
+func (r *MemberRepository) GetWaitlistPosition(ctx context.Context, channelID, userID string) (int, error) {
+	var position int
+	query := `SELECT COUNT(*) FROM channel_waitlist w
+		INNER JOIN channel_waitlist me ON me.channel_id = w.channel_id AND me.user_id = ?
+		WHERE w.channel_id = ? AND (w.created_at < me.created_at OR (w.created_at = me.created_at AND w.user_id <= me.user_id))`
+	err := r.db.GetContext(ctx, &position, query, userID, channelID)
+	return position, err
+}
+
+func (r *MemberRepository) ListWaitlist(ctx context.Context, channelID string) ([]*models.WaitlistEntry, error) {
+	var entries []*models.WaitlistEntry
+	query := `SELECT * FROM channel_waitlist WHERE channel_id = ? ORDER BY created_at, user_id`
+	err := r.db.SelectContext(ctx, &entries, query, channelID)
+	return entries, err
+}
+
+func (r *MemberRepository) RemoveFromWaitlist(ctx context.Context, channelID, userID string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM channel_waitlist WHERE channel_id = ? AND user_id = ?`, channelID, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// AdmitFromWaitlist adds waitlisted members, in the order given, into any
+// free slots under limit and removes admitted users (and users who meanwhile
+// joined another way) from the waitlist in the same transaction.
+func (r *MemberRepository) AdmitFromWaitlist(ctx context.Context, channelID string, members []*models.ChannelMember, limit int) ([]*models.ChannelMember, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	added, existing, _, err := addMembersWithinLimit(ctx, tx, channelID, members, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dequeue := existing
+	for _, m := range added {
+		dequeue = append(dequeue, m.UserID)
+	}
+	for _, userID := range dequeue {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM channel_waitlist WHERE channel_id = ? AND user_id = ?`, channelID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return added, tx.Commit()
+}
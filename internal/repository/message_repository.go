@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+type MessageRepository struct {
+	db *sqlx.DB
+}
+
+func NewMessageRepository(db *sqlx.DB) *MessageRepository {
+	return &MessageRepository{db: db}
+}
+
+// RecordCreated stores a message together with its counter, mention and
+// daily rollup (under metric) updates in one transaction. The message ID is
+// the idempotency key: replays, and messages whose delete event arrived
+// first, report false and change nothing.
+func (r *MessageRepository) RecordCreated(ctx context.Context, msg *models.ChannelMessage, mentions []string, metric string) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `INSERT IGNORE INTO channel_messages (id, channel_id, user_id, thread_id, created_at) VALUES (?, ?, ?, ?, ?)`,
+		msg.ID, msg.ChannelID, msg.UserID, msg.ThreadID, msg.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+
+	counterQuery := `INSERT INTO channel_counters (channel_id, message_count, last_message_at, last_activity_at, updated_at) VALUES (?, 1, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE message_count = message_count + 1,
+			last_message_at = GREATEST(COALESCE(last_message_at, VALUES(last_message_at)), VALUES(last_message_at)),
+			last_activity_at = GREATEST(COALESCE(last_activity_at, VALUES(last_activity_at)), VALUES(last_activity_at)),
+			updated_at = VALUES(updated_at)`
+	if _, err := tx.ExecContext(ctx, counterQuery, msg.ChannelID, msg.CreatedAt, msg.CreatedAt, time.Now()); err != nil {
+		return false, err
+	}
+
+	for _, userID := range mentions {
+		if _, err := tx.ExecContext(ctx, `INSERT IGNORE INTO channel_mentions (message_id, channel_id, user_id, created_at) VALUES (?, ?, ?, ?)`,
+			msg.ID, msg.ChannelID, userID, msg.CreatedAt); err != nil {
+			return false, err
+		}
+	}
+
+	if err := recordActivity(ctx, tx, msg.ChannelID, msg.UserID, metric, msg.CreatedAt); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// RecordDeleted soft-deletes a message and reverses its counter and mention
+// updates. Deleting an unknown message leaves a tombstone so that a created
+// event delivered late is ignored. It reports whether a live message was
+// removed.
+func (r *MessageRepository) RecordDeleted(ctx context.Context, msg *models.ChannelMessage) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE channel_messages SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`, msg.DeletedAt, msg.ID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if n == 0 {
+		if _, err := tx.ExecContext(ctx, `INSERT IGNORE INTO channel_messages (id, channel_id, user_id, thread_id, created_at, deleted_at) VALUES (?, ?, ?, ?, ?, ?)`,
+			msg.ID, msg.ChannelID, msg.UserID, msg.ThreadID, msg.CreatedAt, msg.DeletedAt); err != nil {
+			return false, err
+		}
+		return false, tx.Commit()
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE channel_counters SET message_count = GREATEST(message_count - 1, 0), updated_at = ? WHERE channel_id = ?`,
+		time.Now(), msg.ChannelID); err != nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM channel_mentions WHERE message_id = ?`, msg.ID); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (r *MessageRepository) GetByID(ctx context.Context, id string) (*models.ChannelMessage, error) {
+	var msg models.ChannelMessage
+	err := r.db.GetContext(ctx, &msg, `SELECT * FROM channel_messages WHERE id = ?`, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &msg, err
+}
+
+func (r *MessageRepository) GetCounters(ctx context.Context, channelID string) (*models.ChannelCounters, error) {
+	var counters models.ChannelCounters
+	err := r.db.GetContext(ctx, &counters, `SELECT * FROM channel_counters WHERE channel_id = ?`, channelID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &counters, err
+}
+
+func (r *MessageRepository) ListUnreadByUser(ctx context.Context, userID, workspaceID string) ([]*models.ChannelUnread, error) {
+	var unread []*models.ChannelUnread
+	query := `SELECT cm.channel_id, cm.last_read_at,
+			(SELECT COUNT(*) FROM channel_messages m
+				WHERE m.channel_id = cm.channel_id AND m.deleted_at IS NULL AND m.user_id <> cm.user_id
+				AND m.created_at > COALESCE(cm.last_read_at, cm.joined_at)) AS unread_count,
+			(SELECT COUNT(*) FROM channel_mentions mn
+				WHERE mn.channel_id = cm.channel_id AND mn.user_id = cm.user_id
+				AND mn.created_at > COALESCE(cm.last_read_at, cm.joined_at)) AS mention_count
+		FROM channel_members cm
+		INNER JOIN channels c ON c.id = cm.channel_id AND c.deleted_at IS NULL
+		WHERE cm.user_id = ? AND (? = '' OR c.workspace_id = ?)
+		ORDER BY mention_count DESC, unread_count DESC`
+	err := r.db.SelectContext(ctx, &unread, query, userID, workspaceID, workspaceID)
+	return unread, err
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/quckapp/channel-service/internal/models"
@@ -103,3 +104,15 @@ func (r *ModerationRepository) GetHistory(ctx context.Context, channelID string,
 	err := r.db.SelectContext(ctx, &entries, query, channelID, limit, offset)
 	return entries, err
 }
+
+// StreamByWorkspace calls fn for every moderation entry in the workspace's
+// channels created in [from, to), oldest first, reading rows one at a time.
+func (r *ModerationRepository) StreamByWorkspace(ctx context.Context, workspaceID string, from, to time.Time, fn func(*models.AuditRecord) error) error {
+	query := `SELECT 'moderation' AS source, m.id, m.channel_id, m.actor_id, m.action, NULL AS target_id,
+			m.user_id AS subject_user_id, m.reason, m.expires_at, NULL AS details, m.created_at
+		FROM channel_moderation_log m
+		INNER JOIN channels c ON c.id = m.channel_id
+		WHERE c.workspace_id = ? AND m.created_at >= ? AND m.created_at < ?
+		ORDER BY m.created_at, m.id`
+	return streamAuditRecords(ctx, r.db, fn, query, workspaceID, from, to)
+}
@@ -17,10 +17,14 @@ func NewPermissionRepository(db *sqlx.DB) *PermissionRepository {
 }
 
 func (r *PermissionRepository) Set(ctx context.Context, perm *models.ChannelPermission) error {
+	return setPermission(ctx, r.db, perm)
+}
+
+func setPermission(ctx context.Context, exec sqlx.ExecerContext, perm *models.ChannelPermission) error {
 	query := `INSERT INTO channel_permissions (id, channel_id, permission_type, target_type, target_id, allow, deny, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON DUPLICATE KEY UPDATE allow = VALUES(allow), deny = VALUES(deny), updated_at = NOW()`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := exec.ExecContext(ctx, query,
 		perm.ID, perm.ChannelID, perm.PermissionType, perm.TargetType, perm.TargetID,
 		perm.Allow, perm.Deny, perm.CreatedAt, perm.UpdatedAt)
 	return err
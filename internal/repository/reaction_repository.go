@@ -16,12 +16,18 @@ func NewReactionRepository(db *sqlx.DB) *ReactionRepository {
 	return &ReactionRepository{db: db}
 }
 
-func (r *ReactionRepository) Create(ctx context.Context, reaction *models.ChannelReaction) error {
-	query := `INSERT INTO channel_reactions (id, channel_id, message_id, user_id, emoji, created_at)
+// Create adds the reaction and reports whether it was new; a concurrent
+// toggle may already have added the same one.
+func (r *ReactionRepository) Create(ctx context.Context, reaction *models.ChannelReaction) (bool, error) {
+	query := `INSERT IGNORE INTO channel_reactions (id, channel_id, message_id, user_id, emoji, created_at)
 		VALUES (?, ?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query,
+	res, err := r.db.ExecContext(ctx, query,
 		reaction.ID, reaction.ChannelID, reaction.MessageID, reaction.UserID, reaction.Emoji, reaction.CreatedAt)
-	return err
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
 }
 
 func (r *ReactionRepository) Delete(ctx context.Context, channelID, messageID, userID, emoji string) error {
@@ -60,3 +66,15 @@ func (r *ReactionRepository) GetByID(ctx context.Context, id string) (*models.Ch
 	}
 	return &reaction, err
 }
+
+func (r *ReactionRepository) GetSummaries(ctx context.Context, channelID, userID string, messageIDs []string) ([]*models.MessageReactionSummary, error) {
+	var summaries []*models.MessageReactionSummary
+	query, args, err := sqlx.In(`SELECT message_id, emoji, COUNT(*) AS count, MAX(user_id = ?) AS reacted
+		FROM channel_reactions WHERE channel_id = ? AND message_id IN (?)
+		GROUP BY message_id, emoji ORDER BY message_id, count DESC`, userID, channelID, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.SelectContext(ctx, &summaries, r.db.Rebind(query), args...)
+	return summaries, err
+}
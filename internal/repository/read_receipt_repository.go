@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/quckapp/channel-service/internal/models"
@@ -15,10 +16,15 @@ func NewReadReceiptRepository(db *sqlx.DB) *ReadReceiptRepository {
 	return &ReadReceiptRepository{db: db}
 }
 
-func (r *ReadReceiptRepository) Upsert(ctx context.Context, receipt *models.ReadReceipt) error {
+// Upsert records the user's receipt for a message created at position. An
+// existing receipt only moves to a message at or after the one it points to.
+func (r *ReadReceiptRepository) Upsert(ctx context.Context, receipt *models.ReadReceipt, position time.Time) error {
 	query := `INSERT INTO channel_read_receipts (id, channel_id, user_id, message_id, read_at) VALUES (?, ?, ?, ?, ?)
-		ON DUPLICATE KEY UPDATE message_id = VALUES(message_id), read_at = VALUES(read_at)`
-	_, err := r.db.ExecContext(ctx, query, receipt.ID, receipt.ChannelID, receipt.UserID, receipt.MessageID, receipt.ReadAt)
+		ON DUPLICATE KEY UPDATE
+			message_id = IF(COALESCE((SELECT m.created_at FROM channel_messages m WHERE m.id = channel_read_receipts.message_id) <= ?, TRUE),
+				VALUES(message_id), message_id),
+			read_at = IF(message_id = VALUES(message_id), VALUES(read_at), read_at)`
+	_, err := r.db.ExecContext(ctx, query, receipt.ID, receipt.ChannelID, receipt.UserID, receipt.MessageID, receipt.ReadAt, position)
 	return err
 }
 
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// reorderPositions sets position to 1..n following ids, which must list
+// exactly the rows of table matching scope (column = value pairs) by
+// idColumn. The rows are locked for the check and rewrite, and it returns
+// false without writing if the sets differ.
+func reorderPositions(ctx context.Context, db *sqlx.DB, table, idColumn string, scope map[string]string, ids []string) (bool, error) {
+	columns := make([]string, 0, len(scope))
+	for column := range scope {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	conds := make([]string, len(columns))
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		conds[i] = column + " = ?"
+		args[i] = scope[column]
+	}
+	where := strings.Join(conds, " AND ")
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var current []string
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE %s FOR UPDATE`, idColumn, table, where)
+	if err := tx.SelectContext(ctx, &current, query, args...); err != nil {
+		return false, err
+	}
+	if len(current) != len(ids) {
+		return false, nil
+	}
+	remaining := make(map[string]bool, len(current))
+	for _, id := range current {
+		remaining[id] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return false, nil
+		}
+		delete(remaining, id)
+	}
+
+	update := fmt.Sprintf(`UPDATE %s SET position = ? WHERE %s AND %s = ?`, table, where, idColumn)
+	for i, id := range ids {
+		updateArgs := append(append([]interface{}{i + 1}, args...), id)
+		if _, err := tx.ExecContext(ctx, update, updateArgs...); err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit()
+}
@@ -43,12 +43,41 @@ func (r *SectionRepository) ListByUser(ctx context.Context, workspaceID, userID
 	return sections, err
 }
 
+// Update saves the section's name and collapsed state. Its channel list
+// only changes through UpdateLocked.
 func (r *SectionRepository) Update(ctx context.Context, section *models.ChannelSection) error {
-	query := `UPDATE channel_sections SET name = ?, is_collapsed = ?, channel_ids = ?, updated_at = NOW() WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, section.Name, section.IsCollapsed, section.ChannelIDs, section.ID)
+	query := `UPDATE channel_sections SET name = ?, is_collapsed = ?, updated_at = NOW() WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, section.Name, section.IsCollapsed, section.ID)
 	return err
 }
 
+// UpdateLocked locks every section of the user, in all workspaces, hands
+// them to fn and saves the sections fn returns, all in one transaction, so
+// concurrent edits of the channel lists cannot interleave.
+func (r *SectionRepository) UpdateLocked(ctx context.Context, userID string, fn func([]*models.ChannelSection) ([]*models.ChannelSection, error)) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sections []*models.ChannelSection
+	if err := tx.SelectContext(ctx, &sections, `SELECT * FROM channel_sections WHERE user_id = ? ORDER BY id FOR UPDATE`, userID); err != nil {
+		return err
+	}
+	changed, err := fn(sections)
+	if err != nil {
+		return err
+	}
+	for _, section := range changed {
+		if _, err := tx.ExecContext(ctx, `UPDATE channel_sections SET channel_ids = ?, updated_at = NOW() WHERE id = ?`, section.ChannelIDs, section.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 func (r *SectionRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM channel_sections WHERE id = ?`
 	_, err := r.db.ExecContext(ctx, query, id)
@@ -64,3 +93,19 @@ func (r *SectionRepository) GetMaxPosition(ctx context.Context, workspaceID, use
 	}
 	return int(pos.Int64), nil
 }
+
+// ListByUserAndChannel returns the user's sections, in any workspace, that
+// contain channelID.
+func (r *SectionRepository) ListByUserAndChannel(ctx context.Context, userID, channelID string) ([]*models.ChannelSection, error) {
+	var sections []*models.ChannelSection
+	query := `SELECT * FROM channel_sections WHERE user_id = ? AND JSON_CONTAINS(channel_ids, JSON_QUOTE(?))`
+	err := r.db.SelectContext(ctx, &sections, query, userID, channelID)
+	return sections, err
+}
+
+// Reorder assigns positions following sectionIDs, which must list exactly
+// the user's sections in the workspace. It returns false if the sets differ.
+func (r *SectionRepository) Reorder(ctx context.Context, workspaceID, userID string, sectionIDs []string) (bool, error) {
+	scope := map[string]string{"workspace_id": workspaceID, "user_id": userID}
+	return reorderPositions(ctx, r.db, "channel_sections", "id", scope, sectionIDs)
+}
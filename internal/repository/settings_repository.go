@@ -27,10 +27,50 @@ func (r *SettingsRepository) Get(ctx context.Context, channelID string) (*models
 }
 
 func (r *SettingsRepository) Upsert(ctx context.Context, setting *models.ChannelSetting) error {
-	query := `INSERT INTO channel_settings (id, channel_id, slow_mode_interval, max_pins, max_bookmarks, allow_threads, allow_reactions, allow_invites, auto_archive_days, default_notification, custom_emoji, link_previews, member_limit, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON DUPLICATE KEY UPDATE slow_mode_interval = VALUES(slow_mode_interval), max_pins = VALUES(max_pins), max_bookmarks = VALUES(max_bookmarks), allow_threads = VALUES(allow_threads), allow_reactions = VALUES(allow_reactions), allow_invites = VALUES(allow_invites), auto_archive_days = VALUES(auto_archive_days), default_notification = VALUES(default_notification), custom_emoji = VALUES(custom_emoji), link_previews = VALUES(link_previews), member_limit = VALUES(member_limit), updated_at = ?`
-	_, err := r.db.ExecContext(ctx, query, setting.ID, setting.ChannelID, setting.SlowModeInterval, setting.MaxPins, setting.MaxBookmarks, setting.AllowThreads, setting.AllowReactions, setting.AllowInvites, setting.AutoArchiveDays, setting.DefaultNotification, setting.CustomEmoji, setting.LinkPreviews, setting.MemberLimit, setting.CreatedAt, setting.UpdatedAt, time.Now())
+	return upsertSettings(ctx, r.db, setting)
+}
+
+// UpdateLocked reads the channel's settings, or defaults when it has none,
+// hands them to fn and saves them if fn reports a change. The channel row
+// is locked for the read-modify-write, so concurrent partial updates apply
+// one after the other instead of overwriting each other.
+func (r *SettingsRepository) UpdateLocked(ctx context.Context, channelID string, defaults *models.ChannelSetting, fn func(*models.ChannelSetting) bool) (*models.ChannelSetting, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Locking the channel rather than only the settings row also covers
+	// channels that have no settings row yet.
+	var locked string
+	if err := tx.GetContext(ctx, &locked, `SELECT id FROM channels WHERE id = ? FOR UPDATE`, channelID); err != nil {
+		return nil, err
+	}
+
+	var setting models.ChannelSetting
+	err = tx.GetContext(ctx, &setting, `SELECT * FROM channel_settings WHERE channel_id = ? FOR UPDATE`, channelID)
+	switch {
+	case err == sql.ErrNoRows:
+		setting = *defaults
+	case err != nil:
+		return nil, err
+	}
+
+	if !fn(&setting) {
+		return &setting, nil
+	}
+	if err := upsertSettings(ctx, tx, &setting); err != nil {
+		return nil, err
+	}
+	return &setting, tx.Commit()
+}
+
+func upsertSettings(ctx context.Context, exec sqlx.ExecerContext, setting *models.ChannelSetting) error {
+	query := `INSERT INTO channel_settings (id, channel_id, slow_mode_interval, max_pins, max_bookmarks, allow_threads, allow_reactions, allow_invites, auto_archive_days, default_notification, custom_emoji, link_previews, member_limit, voice_stage_mode, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE slow_mode_interval = VALUES(slow_mode_interval), max_pins = VALUES(max_pins), max_bookmarks = VALUES(max_bookmarks), allow_threads = VALUES(allow_threads), allow_reactions = VALUES(allow_reactions), allow_invites = VALUES(allow_invites), auto_archive_days = VALUES(auto_archive_days), default_notification = VALUES(default_notification), custom_emoji = VALUES(custom_emoji), link_previews = VALUES(link_previews), member_limit = VALUES(member_limit), voice_stage_mode = VALUES(voice_stage_mode), updated_at = ?`
+	_, err := exec.ExecContext(ctx, query, setting.ID, setting.ChannelID, setting.SlowModeInterval, setting.MaxPins, setting.MaxBookmarks, setting.AllowThreads, setting.AllowReactions, setting.AllowInvites, setting.AutoArchiveDays, setting.DefaultNotification, setting.CustomEmoji, setting.LinkPreviews, setting.MemberLimit, setting.VoiceStageMode, setting.CreatedAt, setting.UpdatedAt, time.Now())
 	return err
 }
 
@@ -38,3 +78,38 @@ func (r *SettingsRepository) Delete(ctx context.Context, channelID string) error
 	_, err := r.db.ExecContext(ctx, `DELETE FROM channel_settings WHERE channel_id = ?`, channelID)
 	return err
 }
+
+// ListAutoArchiveCandidates returns unarchived channels with auto-archive
+// enabled whose last activity is within warningDays of the threshold or
+// past it, with when their owners were warned about that idle period. An
+// empty workspaceID matches every workspace.
+func (r *SettingsRepository) ListAutoArchiveCandidates(ctx context.Context, workspaceID string, now time.Time, warningDays int) ([]*models.AutoArchiveCandidate, error) {
+	var candidates []*models.AutoArchiveCandidate
+	query := `SELECT c.id as channel_id, c.workspace_id, c.name, s.auto_archive_days,
+			COALESCE(cc.last_activity_at, c.created_at) as last_activity_at, w.warned_at
+		FROM channel_settings s
+		INNER JOIN channels c ON c.id = s.channel_id
+		LEFT JOIN channel_counters cc ON cc.channel_id = c.id
+		LEFT JOIN channel_archive_warnings w ON w.channel_id = c.id
+			AND w.last_activity_at = COALESCE(cc.last_activity_at, c.created_at)
+		WHERE s.auto_archive_days > 0 AND c.is_archived = FALSE AND c.deleted_at IS NULL
+			AND (? = '' OR c.workspace_id = ?)
+			AND COALESCE(cc.last_activity_at, c.created_at) < DATE_SUB(?, INTERVAL GREATEST(s.auto_archive_days - ?, 0) DAY)
+		ORDER BY last_activity_at ASC`
+	err := r.db.SelectContext(ctx, &candidates, query, workspaceID, workspaceID, now, warningDays)
+	return candidates, err
+}
+
+// ClaimArchiveWarning records that owners were warned about the idle period
+// ending at lastActivityAt. It returns false if that warning was already sent.
+func (r *SettingsRepository) ClaimArchiveWarning(ctx context.Context, channelID string, lastActivityAt time.Time) (bool, error) {
+	query := `INSERT INTO channel_archive_warnings (channel_id, last_activity_at, warned_at) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE warned_at = IF(last_activity_at = VALUES(last_activity_at), warned_at, VALUES(warned_at)),
+			last_activity_at = VALUES(last_activity_at)`
+	res, err := r.db.ExecContext(ctx, query, channelID, lastActivityAt, time.Now())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -53,3 +53,29 @@ func (r *StarredRepository) CountByUser(ctx context.Context, userID string) (int
 	err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM starred_channels WHERE user_id = ?`, userID)
 	return count, err
 }
+
+// ListChannelsByUser returns the user's starred channels, joined with the
+// channel rows in a single query. Deleted channels are skipped.
+func (r *StarredRepository) ListChannelsByUser(ctx context.Context, userID, workspaceID string) ([]*models.StarredChannelDetails, error) {
+	var channels []*models.StarredChannelDetails
+	query := `SELECT c.*, s.position, s.created_at as starred_at FROM starred_channels s
+		INNER JOIN channels c ON c.id = s.channel_id
+		WHERE s.user_id = ? AND c.deleted_at IS NULL AND (? = '' OR c.workspace_id = ?)
+		ORDER BY s.position, s.created_at`
+	err := r.db.SelectContext(ctx, &channels, query, userID, workspaceID, workspaceID)
+	return channels, err
+}
+
+// PurgeDeleted unstars channels of the user that have since been deleted.
+func (r *StarredRepository) PurgeDeleted(ctx context.Context, userID string) error {
+	query := `DELETE s FROM starred_channels s INNER JOIN channels c ON c.id = s.channel_id
+		WHERE s.user_id = ? AND c.deleted_at IS NOT NULL`
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+// Reorder assigns positions following channelIDs, which must list exactly
+// the user's starred channels. It returns false if the sets differ.
+func (r *StarredRepository) Reorder(ctx context.Context, userID string, channelIDs []string) (bool, error) {
+	return reorderPositions(ctx, r.db, "starred_channels", "channel_id", map[string]string{"user_id": userID}, channelIDs)
+}
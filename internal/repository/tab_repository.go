@@ -17,9 +17,13 @@ func NewTabRepository(db *sqlx.DB) *TabRepository {
 }
 
 func (r *TabRepository) Create(ctx context.Context, tab *models.ChannelTab) error {
+	return insertTab(ctx, r.db, tab)
+}
+
+func insertTab(ctx context.Context, exec sqlx.ExecerContext, tab *models.ChannelTab) error {
 	query := `INSERT INTO channel_tabs (id, channel_id, name, tab_type, config, position, created_by, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query, tab.ID, tab.ChannelID, tab.Name, tab.TabType, tab.Config, tab.Position, tab.CreatedBy, tab.CreatedAt, tab.UpdatedAt)
+	_, err := exec.ExecContext(ctx, query, tab.ID, tab.ChannelID, tab.Name, tab.TabType, tab.Config, tab.Position, tab.CreatedBy, tab.CreatedAt, tab.UpdatedAt)
 	return err
 }
 
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -17,13 +18,79 @@ func NewTemplateRepository(db *sqlx.DB) *TemplateRepository {
 	return &TemplateRepository{db: db}
 }
 
-func (r *TemplateRepository) Create(ctx context.Context, t *models.ChannelTemplate) error {
-	query := `INSERT INTO channel_templates (id, name, description, created_by, channel_type, default_topic, default_tabs, default_settings, is_public, use_count, created_at, updated_at)
+// Create inserts t and records its contents as version 1.
+func (r *TemplateRepository) Create(ctx context.Context, t *models.ChannelTemplate, v *models.ChannelTemplateVersion) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO channel_templates (id, name, description, created_by, workspace_id, visibility, channel_type, default_topic, default_tabs, default_settings, default_bookmarks, default_permissions, source_channel_id, version, is_public, use_count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	if _, err := tx.ExecContext(ctx, query, t.ID, t.Name, t.Description, t.CreatedBy, t.WorkspaceID, t.Visibility, t.ChannelType, t.DefaultTopic, t.DefaultTabs, t.DefaultSettings,
+		t.DefaultBookmarks, t.DefaultPermissions, t.SourceChannelID, t.Version, t.IsPublic, t.UseCount, t.CreatedAt, t.UpdatedAt); err != nil {
+		return err
+	}
+	if err := insertTemplateVersion(ctx, tx, v); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AddVersion makes v the template's current version, numbering it after
+// the latest one. Earlier versions are kept unchanged.
+func (r *TemplateRepository) AddVersion(ctx context.Context, v *models.ChannelTemplateVersion) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current int
+	if err := tx.GetContext(ctx, &current, `SELECT version FROM channel_templates WHERE id = ? FOR UPDATE`, v.TemplateID); err != nil {
+		return err
+	}
+	v.Version = current + 1
+	if err := insertTemplateVersion(ctx, tx, v); err != nil {
+		return err
+	}
+
+	query := `UPDATE channel_templates SET channel_type = ?, default_topic = ?, default_tabs = ?, default_settings = ?, default_bookmarks = ?, default_permissions = ?,
+			source_channel_id = ?, version = ?, updated_at = ?
+		WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, query, v.ChannelType, v.DefaultTopic, v.DefaultTabs, v.DefaultSettings, v.DefaultBookmarks, v.DefaultPermissions,
+		v.SourceChannelID, v.Version, v.CreatedAt, v.TemplateID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertTemplateVersion(ctx context.Context, exec sqlx.ExecerContext, v *models.ChannelTemplateVersion) error {
+	query := `INSERT INTO channel_template_versions (id, template_id, version, channel_type, default_topic, default_tabs, default_settings, default_bookmarks, default_permissions, source_channel_id, created_by, created_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query, t.ID, t.Name, t.Description, t.CreatedBy, t.ChannelType, t.DefaultTopic, t.DefaultTabs, t.DefaultSettings, t.IsPublic, t.UseCount, t.CreatedAt, t.UpdatedAt)
+	_, err := exec.ExecContext(ctx, query, v.ID, v.TemplateID, v.Version, v.ChannelType, v.DefaultTopic, v.DefaultTabs, v.DefaultSettings,
+		v.DefaultBookmarks, v.DefaultPermissions, v.SourceChannelID, v.CreatedBy, v.CreatedAt)
 	return err
 }
 
+func (r *TemplateRepository) GetVersion(ctx context.Context, templateID string, version int) (*models.ChannelTemplateVersion, error) {
+	var v models.ChannelTemplateVersion
+	err := r.db.GetContext(ctx, &v, `SELECT * FROM channel_template_versions WHERE template_id = ? AND version = ?`, templateID, version)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &v, err
+}
+
+func (r *TemplateRepository) ListVersions(ctx context.Context, templateID string) ([]*models.ChannelTemplateVersion, error) {
+	var versions []*models.ChannelTemplateVersion
+	err := r.db.SelectContext(ctx, &versions, `SELECT * FROM channel_template_versions WHERE template_id = ? ORDER BY version DESC`, templateID)
+	return versions, err
+}
+
 func (r *TemplateRepository) GetByID(ctx context.Context, id string) (*models.ChannelTemplate, error) {
 	var t models.ChannelTemplate
 	err := r.db.GetContext(ctx, &t, "SELECT * FROM channel_templates WHERE id = ?", id)
@@ -33,10 +100,48 @@ func (r *TemplateRepository) GetByID(ctx context.Context, id string) (*models.Ch
 	return &t, err
 }
 
-func (r *TemplateRepository) List(ctx context.Context) ([]*models.ChannelTemplate, error) {
+// List returns the templates filter.UserID can see. With a WorkspaceID
+// that is the global templates plus the workspace's own, leaving out other
+// users' private ones; without, the global templates plus the user's own.
+// Query matches names by substring.
+func (r *TemplateRepository) List(ctx context.Context, filter *models.TemplateFilter, limit, offset int) ([]*models.ChannelTemplate, int, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.WorkspaceID != "" {
+		conditions = append(conditions, "(visibility = 'global' OR workspace_id = ?)",
+			"(visibility <> 'private' OR created_by = ?)")
+		args = append(args, filter.WorkspaceID, filter.UserID)
+	} else {
+		conditions = append(conditions, "(visibility = 'global' OR created_by = ?)")
+		args = append(args, filter.UserID)
+	}
+	if filter.Query != "" {
+		conditions = append(conditions, "name LIKE ?")
+		args = append(args, "%"+escapeLike(filter.Query)+"%")
+	}
+	where := strings.Join(conditions, " AND ")
+
+	order := "created_at DESC, id DESC"
+	if filter.Sort == "popular" {
+		order = "use_count DESC, created_at DESC, id DESC"
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM channel_templates WHERE `+where, args...); err != nil {
+		return nil, 0, err
+	}
+
 	var templates []*models.ChannelTemplate
-	err := r.db.SelectContext(ctx, &templates, "SELECT * FROM channel_templates WHERE is_public = TRUE ORDER BY use_count DESC, created_at DESC")
-	return templates, err
+	query := `SELECT * FROM channel_templates WHERE ` + where + ` ORDER BY ` + order + ` LIMIT ? OFFSET ?`
+	err := r.db.SelectContext(ctx, &templates, query, append(args, limit, offset)...)
+	return templates, total, err
+}
+
+// Update saves a template's name, description and visibility.
+func (r *TemplateRepository) Update(ctx context.Context, t *models.ChannelTemplate) error {
+	query := `UPDATE channel_templates SET name = ?, description = ?, visibility = ?, is_public = ?, updated_at = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, t.Name, t.Description, t.Visibility, t.IsPublic, t.UpdatedAt, t.ID)
+	return err
 }
 
 func (r *TemplateRepository) Delete(ctx context.Context, id string) error {
@@ -48,3 +153,56 @@ func (r *TemplateRepository) IncrementUseCount(ctx context.Context, id string) e
 	_, err := r.db.ExecContext(ctx, "UPDATE channel_templates SET use_count = use_count + 1, updated_at = ? WHERE id = ?", time.Now(), id)
 	return err
 }
+
+// AppliedTemplate is everything applying a template writes.
+type AppliedTemplate struct {
+	Channel     *models.Channel
+	Owner       *models.ChannelMember
+	Settings    *models.ChannelSetting
+	Tabs        []*models.ChannelTab
+	Bookmarks   []*models.ChannelBookmark
+	Permissions []*models.ChannelPermission
+}
+
+// Apply creates the channel from template id in one transaction: the
+// channel, its owner, settings (if any), tabs, bookmarks and permission
+// overrides, and counts the use. Nothing is written if any step fails.
+func (r *TemplateRepository) Apply(ctx context.Context, id string, a *AppliedTemplate) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertChannel(ctx, tx, a.Channel); err != nil {
+		return err
+	}
+	if _, _, _, err := addMembersWithinLimit(ctx, tx, a.Channel.ID, []*models.ChannelMember{a.Owner}, 0); err != nil {
+		return err
+	}
+	if a.Settings != nil {
+		if err := upsertSettings(ctx, tx, a.Settings); err != nil {
+			return err
+		}
+	}
+	for _, tab := range a.Tabs {
+		if err := insertTab(ctx, tx, tab); err != nil {
+			return err
+		}
+	}
+	for _, bookmark := range a.Bookmarks {
+		if err := insertBookmark(ctx, tx, bookmark); err != nil {
+			return err
+		}
+	}
+	for _, perm := range a.Permissions {
+		if err := setPermission(ctx, tx, perm); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE channel_templates SET use_count = use_count + 1, updated_at = ? WHERE id = ?", time.Now(), id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/quckapp/channel-service/internal/models"
@@ -16,16 +17,39 @@ func NewTopicHistoryRepository(db *sqlx.DB) *TopicHistoryRepository {
 }
 
 func (r *TopicHistoryRepository) Create(ctx context.Context, entry *models.TopicHistory) error {
+	return insertTopicHistory(ctx, r.db, entry)
+}
+
+func insertTopicHistory(ctx context.Context, exec sqlx.ExecerContext, entry *models.TopicHistory) error {
 	query := `INSERT INTO channel_topic_history (id, channel_id, old_topic, new_topic, changed_by, changed_at)
 		VALUES (?, ?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := exec.ExecContext(ctx, query,
 		entry.ID, entry.ChannelID, entry.OldTopic, entry.NewTopic, entry.ChangedBy, entry.ChangedAt)
 	return err
 }
 
+func (r *TopicHistoryRepository) GetByID(ctx context.Context, id string) (*models.TopicHistory, error) {
+	var entry models.TopicHistory
+	err := r.db.GetContext(ctx, &entry, `SELECT * FROM channel_topic_history WHERE id = ?`, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &entry, err
+}
+
+func (r *TopicHistoryRepository) GetLatest(ctx context.Context, channelID string) (*models.TopicHistory, error) {
+	var entry models.TopicHistory
+	query := `SELECT * FROM channel_topic_history WHERE channel_id = ? ORDER BY changed_at DESC, id DESC LIMIT 1`
+	err := r.db.GetContext(ctx, &entry, query, channelID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &entry, err
+}
+
 func (r *TopicHistoryRepository) ListByChannel(ctx context.Context, channelID string, limit, offset int) ([]*models.TopicHistory, error) {
 	var entries []*models.TopicHistory
-	query := `SELECT * FROM channel_topic_history WHERE channel_id = ? ORDER BY changed_at DESC LIMIT ? OFFSET ?`
+	query := `SELECT * FROM channel_topic_history WHERE channel_id = ? ORDER BY changed_at DESC, id DESC LIMIT ? OFFSET ?`
 	err := r.db.SelectContext(ctx, &entries, query, channelID, limit, offset)
 	return entries, err
 }
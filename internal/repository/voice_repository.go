@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -17,17 +19,54 @@ func NewVoiceRepository(db *sqlx.DB) *VoiceRepository {
 	return &VoiceRepository{db: db}
 }
 
-func (r *VoiceRepository) Join(ctx context.Context, state *models.VoiceChannelState) error {
-	query := `INSERT INTO voice_channel_states (id, channel_id, user_id, is_muted, is_deafened, is_screen_share, is_video_on, joined_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query, state.ID, state.ChannelID, state.UserID, state.IsMuted, state.IsDeafened, state.IsScreenShare, state.IsVideoOn, state.JoinedAt)
-	return err
+// Join makes state the user's only active voice session. Any session the
+// user has in another channel is disconnected in the same transaction and
+// returned. If the user is already in state's channel that session is kept,
+// state is left untouched and joined is false.
+func (r *VoiceRepository) Join(ctx context.Context, state *models.VoiceChannelState) (left []*models.VoiceChannelState, joined bool, err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	var active []*models.VoiceChannelState
+	if err := tx.SelectContext(ctx, &active, `SELECT * FROM voice_channel_states WHERE user_id = ? AND disconnected_at IS NULL FOR UPDATE`, state.UserID); err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	for _, s := range active {
+		if s.ChannelID == state.ChannelID {
+			return nil, false, nil
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE voice_channel_states SET disconnected_at = ? WHERE id = ?`, now, s.ID); err != nil {
+			return nil, false, err
+		}
+		s.DisconnectedAt = &now
+		left = append(left, s)
+	}
+
+	query := `INSERT INTO voice_channel_states (id, channel_id, user_id, is_muted, is_deafened, is_screen_share, is_video_on, joined_at, is_server_muted, is_server_deafened, is_speaker)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	if _, err := tx.ExecContext(ctx, query, state.ID, state.ChannelID, state.UserID, state.IsMuted, state.IsDeafened, state.IsScreenShare, state.IsVideoOn, state.JoinedAt,
+		state.IsServerMuted, state.IsServerDeafened, state.IsSpeaker); err != nil {
+		return nil, false, err
+	}
+
+	return left, true, tx.Commit()
 }
 
-func (r *VoiceRepository) Leave(ctx context.Context, channelID, userID string) error {
-	_, err := r.db.ExecContext(ctx, `UPDATE voice_channel_states SET disconnected_at = ? WHERE channel_id = ? AND user_id = ? AND disconnected_at IS NULL`,
+// Leave disconnects the user's active session in the channel and reports
+// whether there was one.
+func (r *VoiceRepository) Leave(ctx context.Context, channelID, userID string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE voice_channel_states SET disconnected_at = ? WHERE channel_id = ? AND user_id = ? AND disconnected_at IS NULL`,
 		time.Now(), channelID, userID)
-	return err
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
 }
 
 func (r *VoiceRepository) GetState(ctx context.Context, channelID, userID string) (*models.VoiceChannelState, error) {
@@ -39,6 +78,84 @@ func (r *VoiceRepository) GetState(ctx context.Context, channelID, userID string
 	return &state, err
 }
 
+// UpdateModeration saves the server mute, deafen, speaker and raised hand
+// state of an active session, along with the self-mute flag that stage
+// demotion forces on. It reports whether the session was still active.
+func (r *VoiceRepository) UpdateModeration(ctx context.Context, state *models.VoiceChannelState) (bool, error) {
+	query := `UPDATE voice_channel_states SET is_muted = ?, is_server_muted = ?, is_server_deafened = ?, is_speaker = ?, hand_raised_at = ?
+		WHERE id = ? AND disconnected_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, state.IsMuted, state.IsServerMuted, state.IsServerDeafened, state.IsSpeaker, state.HandRaisedAt, state.ID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// ApplyStageMode brings the channel's active sessions in line with a stage
+// mode change and returns the sessions it changed. Turning stage mode on
+// demotes and mutes everyone who is not a channel owner or admin; turning it
+// off makes everyone a speaker again and lowers raised hands.
+func (r *VoiceRepository) ApplyStageMode(ctx context.Context, channelID string, stage bool) ([]*models.VoiceChannelState, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var states []*models.VoiceChannelState
+	query := `SELECT v.* FROM voice_channel_states v
+		WHERE v.channel_id = ? AND v.disconnected_at IS NULL AND (v.is_speaker = FALSE OR v.hand_raised_at IS NOT NULL)
+		FOR UPDATE`
+	if stage {
+		query = `SELECT v.* FROM voice_channel_states v
+			LEFT JOIN channel_members m ON m.channel_id = v.channel_id AND m.user_id = v.user_id
+			WHERE v.channel_id = ? AND v.disconnected_at IS NULL AND COALESCE(m.role, '') NOT IN ('owner', 'admin')
+				AND (v.is_speaker = TRUE OR v.is_muted = FALSE OR v.hand_raised_at IS NOT NULL)
+			FOR UPDATE`
+	}
+	if err := tx.SelectContext(ctx, &states, query, channelID); err != nil {
+		return nil, err
+	}
+	if len(states) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(states))
+	for i, state := range states {
+		ids[i] = state.ID
+		state.IsSpeaker = !stage
+		state.HandRaisedAt = nil
+		if stage {
+			state.IsMuted = true
+		}
+	}
+
+	update := `UPDATE voice_channel_states SET is_speaker = TRUE, hand_raised_at = NULL WHERE id IN (?)`
+	if stage {
+		update = `UPDATE voice_channel_states SET is_speaker = FALSE, is_muted = TRUE, hand_raised_at = NULL WHERE id IN (?)`
+	}
+	update, updateArgs, err := sqlx.In(update, ids)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind(update), updateArgs...); err != nil {
+		return nil, err
+	}
+
+	return states, tx.Commit()
+}
+
+// GetActiveByUser returns the user's active session in any channel, or nil.
+func (r *VoiceRepository) GetActiveByUser(ctx context.Context, userID string) (*models.VoiceChannelState, error) {
+	var state models.VoiceChannelState
+	err := r.db.GetContext(ctx, &state, `SELECT * FROM voice_channel_states WHERE user_id = ? AND disconnected_at IS NULL ORDER BY joined_at DESC LIMIT 1`, userID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &state, err
+}
+
 func (r *VoiceRepository) UpdateState(ctx context.Context, channelID, userID string, req *models.UpdateVoiceStateRequest) error {
 	query := `UPDATE voice_channel_states SET is_muted = COALESCE(?, is_muted), is_deafened = COALESCE(?, is_deafened), is_screen_share = COALESCE(?, is_screen_share), is_video_on = COALESCE(?, is_video_on) WHERE channel_id = ? AND user_id = ? AND disconnected_at IS NULL`
 	_, err := r.db.ExecContext(ctx, query, req.IsMuted, req.IsDeafened, req.IsScreenShare, req.IsVideoOn, channelID, userID)
@@ -56,3 +173,113 @@ func (r *VoiceRepository) CountParticipants(ctx context.Context, channelID strin
 	err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM voice_channel_states WHERE channel_id = ? AND disconnected_at IS NULL`, channelID)
 	return count, err
 }
+
+// ListActiveJoinedBefore pages through active sessions that started before
+// joinedBefore, ordered by ID. Pass the last ID of the previous page as
+// afterID, or "" for the first page.
+func (r *VoiceRepository) ListActiveJoinedBefore(ctx context.Context, joinedBefore time.Time, afterID string, limit int) ([]*models.VoiceChannelState, error) {
+	var states []*models.VoiceChannelState
+	query := `SELECT * FROM voice_channel_states WHERE disconnected_at IS NULL AND id > ? AND joined_at < ? ORDER BY id LIMIT ?`
+	err := r.db.SelectContext(ctx, &states, query, afterID, joinedBefore, limit)
+	return states, err
+}
+
+// Disconnect ends a session at the given time and reports whether it was
+// still active.
+func (r *VoiceRepository) Disconnect(ctx context.Context, id string, at time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE voice_channel_states SET disconnected_at = ? WHERE id = ? AND disconnected_at IS NULL`, at, id)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// ListSessions returns sessions matching filter, newest first, and the total
+// number of matches.
+func (r *VoiceRepository) ListSessions(ctx context.Context, filter *models.VoiceSessionFilter, limit, offset int) ([]*models.VoiceChannelState, int, error) {
+	conditions := []string{"joined_at >= ?", "joined_at < ?"}
+	args := []interface{}{filter.From, filter.To}
+	if filter.ChannelID != "" {
+		conditions = append(conditions, "channel_id = ?")
+		args = append(args, filter.ChannelID)
+	}
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM voice_channel_states WHERE `+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	var sessions []*models.VoiceChannelState
+	query := `SELECT * FROM voice_channel_states WHERE ` + where + ` ORDER BY joined_at DESC, id DESC LIMIT ? OFFSET ?`
+	err := r.db.SelectContext(ctx, &sessions, query, append(args, limit, offset)...)
+	return sessions, total, err
+}
+
+// GetUsageReport summarizes the channel's sessions that overlap [from, to).
+// Durations are clipped to the range, and sessions still in progress count
+// up to now.
+func (r *VoiceRepository) GetUsageReport(ctx context.Context, channelID string, from, to, now time.Time) (*models.VoiceUsageReport, error) {
+	report := &models.VoiceUsageReport{ChannelID: channelID, From: from, To: to}
+
+	query := `SELECT COUNT(*) AS sessions, COUNT(DISTINCT user_id) AS unique_participants,
+			COALESCE(SUM(TIMESTAMPDIFF(SECOND, GREATEST(joined_at, ?), LEAST(COALESCE(disconnected_at, ?), ?))), 0) / 60 AS total_minutes,
+			COALESCE(AVG(TIMESTAMPDIFF(SECOND, GREATEST(joined_at, ?), LEAST(COALESCE(disconnected_at, ?), ?))), 0) / 60 AS average_session_minutes
+		FROM voice_channel_states
+		WHERE channel_id = ? AND joined_at < ? AND (disconnected_at IS NULL OR disconnected_at > ?)`
+	if err := r.db.GetContext(ctx, report, query, from, now, to, from, now, to, channelID, to, from); err != nil {
+		return nil, err
+	}
+
+	// One row per day in the range, including days without sessions.
+	query = `WITH RECURSIVE days AS (
+			SELECT CAST(DATE(?) AS DATETIME) AS day
+			UNION ALL
+			SELECT day + INTERVAL 1 DAY FROM days WHERE day + INTERVAL 1 DAY < ?
+		)
+		SELECT DATE_FORMAT(d.day, '%Y-%m-%d') AS day,
+			COALESCE(SUM(TIMESTAMPDIFF(SECOND, GREATEST(v.joined_at, d.day, ?),
+				LEAST(COALESCE(v.disconnected_at, ?), d.day + INTERVAL 1 DAY, ?))), 0) / 60 AS minutes,
+			COUNT(DISTINCT v.user_id) AS participants
+		FROM days d
+		LEFT JOIN voice_channel_states v ON v.channel_id = ?
+			AND v.joined_at < LEAST(d.day + INTERVAL 1 DAY, ?)
+			AND (v.disconnected_at IS NULL OR v.disconnected_at > GREATEST(d.day, ?))
+		GROUP BY d.day ORDER BY d.day`
+	if err := r.db.SelectContext(ctx, &report.Daily, query, from, to, from, now, to, channelID, to, from); err != nil {
+		return nil, err
+	}
+
+	// Replay joins and leaves in time order; leaves sort first at equal
+	// timestamps so back-to-back sessions are not double counted.
+	var peak struct {
+		PeakAt  time.Time `db:"peak_at"`
+		Running int       `db:"running"`
+	}
+	query = `SELECT ts AS peak_at, running FROM (
+			SELECT ts, SUM(delta) OVER (ORDER BY ts, delta ROWS UNBOUNDED PRECEDING) AS running
+			FROM (
+				SELECT GREATEST(joined_at, ?) AS ts, 1 AS delta FROM voice_channel_states
+				WHERE channel_id = ? AND joined_at < ? AND (disconnected_at IS NULL OR disconnected_at > ?)
+				UNION ALL
+				SELECT disconnected_at AS ts, -1 AS delta FROM voice_channel_states
+				WHERE channel_id = ? AND joined_at < ? AND disconnected_at > ? AND disconnected_at < ?
+			) events
+		) replay
+		ORDER BY running DESC, ts LIMIT 1`
+	err := r.db.GetContext(ctx, &peak, query, from, channelID, to, from, channelID, to, from, to)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil && peak.Running > 0 {
+		report.PeakConcurrent = peak.Running
+		report.PeakAt = &peak.PeakAt
+	}
+
+	return report, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Activity Log ──
+
+const (
+	ActionPollCreated = "poll.created"
+	ActionPollVoted   = "poll.voted"
+	ActionPollClosed  = "poll.closed"
+
+	ActionScheduledMessageCreated   = "scheduled_message.created"
+	ActionScheduledMessageUpdated   = "scheduled_message.updated"
+	ActionScheduledMessageCancelled = "scheduled_message.cancelled"
+
+	ActionLinkCreated = "link.created"
+	ActionLinkDeleted = "link.deleted"
+
+	ActionTabAdded      = "tab.added"
+	ActionTabUpdated    = "tab.updated"
+	ActionTabRemoved    = "tab.removed"
+	ActionTabsReordered = "tab.reordered"
+
+	ActionChannelFollowed   = "follow.created"
+	ActionChannelUnfollowed = "follow.deleted"
+
+	ActionTemplateCreated   = "template.created"
+	ActionTemplateVersioned = "template.versioned"
+	ActionTemplateApplied   = "template.applied"
+
+	defaultActivityLogLimit = 50
+	maxActivityLogLimit     = 200
+)
+
+// GetActivityLog returns a page of the channel's audit trail, newest first.
+// Pass the previous page's NextCursor as cursor to continue.
+func (s *ChannelService) GetActivityLog(ctx context.Context, channelID, userID string, filter *models.ActivityLogFilter, cursor string, limit int) (*models.ActivityLogPage, error) {
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, ErrInvalidTimeRange
+	}
+	if limit <= 0 || limit > maxActivityLogLimit {
+		limit = defaultActivityLogLimit
+	}
+
+	filter.ChannelID = channelID
+	if cursor != "" {
+		before, id, err := decodeActivityCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.BeforeTime = &before
+		filter.BeforeID = id
+	}
+
+	entries, err := s.activityLogRepo.Search(ctx, filter, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.ActivityLogPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		last := page.Entries[limit-1]
+		next := encodeActivityCursor(last.CreatedAt, last.ID)
+		page.NextCursor = &next
+	}
+	if page.Entries == nil {
+		page.Entries = []*models.ChannelActivityLog{}
+	}
+	for _, entry := range page.Entries {
+		decodeActivityDetails(entry)
+	}
+
+	return page, nil
+}
+
+// decodeActivityDetails exposes the stored details as raw JSON.
+func decodeActivityDetails(entry *models.ChannelActivityLog) {
+	if entry.Details != nil && json.Valid([]byte(*entry.Details)) {
+		entry.DetailsJSON = json.RawMessage(*entry.Details)
+	}
+}
+
+func encodeActivityCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.UTC().Format(time.RFC3339Nano) + "|" + id))
+}
+
+func decodeActivityCursor(cursor string) (time.Time, string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	ts, id, ok := strings.Cut(string(data), "|")
+	if !ok || id == "" {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	return createdAt, id, nil
+}
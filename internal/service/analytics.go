@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Analytics ──
+
+const (
+	maxTimeSeriesDays     = 366
+	defaultRetentionWeeks = 8
+	maxRetentionWeeks     = 26
+	maxDashboardChannels  = 20
+)
+
+// recordActivity bumps the daily rollups. Analytics are best-effort, so
+// failures are logged rather than failing the operation being counted.
+func (s *ChannelService) recordActivity(ctx context.Context, channelID, userID, metric string, at time.Time) {
+	if err := s.analyticsRepo.RecordActivity(ctx, channelID, userID, metric, at); err != nil {
+		s.logger.WithError(err).WithField("metric", metric).Warn("Failed to record channel activity")
+	}
+}
+
+func (s *ChannelService) GetChannelStats(ctx context.Context, channelID, userID string) (*models.ChannelStats, error) {
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	return s.analyticsRepo.GetChannelStats(ctx, channelID)
+}
+
+// GetActivityTimeSeries returns rollup totals bucketed by day, week or month.
+// The range defaults to the last 30 days.
+func (s *ChannelService) GetActivityTimeSeries(ctx context.Context, channelID, userID, granularity string, from, to time.Time) ([]*models.ActivityPoint, error) {
+	switch granularity {
+	case "":
+		granularity = "day"
+	case "day", "week", "month":
+	default:
+		return nil, ErrInvalidGranularity
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -29)
+	}
+	if from.After(to) || to.Sub(from) > maxTimeSeriesDays*24*time.Hour {
+		return nil, ErrInvalidTimeRange
+	}
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.analyticsRepo.GetTimeSeries(ctx, channelID, granularity, from, to)
+}
+
+// GetRetentionCohorts groups users by the week they were first active in the
+// channel and reports the share of each cohort active in the weeks after.
+func (s *ChannelService) GetRetentionCohorts(ctx context.Context, channelID, userID string, weeks int) ([]*models.RetentionCohort, error) {
+	if weeks <= 0 {
+		weeks = defaultRetentionWeeks
+	}
+	if weeks > maxRetentionWeeks {
+		weeks = maxRetentionWeeks
+	}
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	weekStart := now.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+	since := weekStart.AddDate(0, 0, -7*(weeks-1))
+
+	rows, err := s.analyticsRepo.GetRetention(ctx, channelID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	cohorts := make([]*models.RetentionCohort, 0)
+	var current *models.RetentionCohort
+	for _, row := range rows {
+		if current == nil || current.CohortStart != row.CohortStart {
+			current = &models.RetentionCohort{CohortStart: row.CohortStart}
+			cohorts = append(cohorts, current)
+		}
+		if row.WeekOffset < 0 {
+			continue
+		}
+		for len(current.ActiveByWeek) <= row.WeekOffset {
+			current.ActiveByWeek = append(current.ActiveByWeek, 0)
+		}
+		current.ActiveByWeek[row.WeekOffset] = row.Users
+	}
+
+	for _, cohort := range cohorts {
+		if len(cohort.ActiveByWeek) > 0 {
+			cohort.Size = cohort.ActiveByWeek[0]
+		}
+		cohort.Retention = make([]float64, len(cohort.ActiveByWeek))
+		for i, active := range cohort.ActiveByWeek {
+			if cohort.Size > 0 {
+				cohort.Retention[i] = float64(active) / float64(cohort.Size)
+			}
+		}
+	}
+
+	return cohorts, nil
+}
+
+func (s *ChannelService) GetTopPosters(ctx context.Context, channelID, userID string, days, limit int) ([]models.MostActiveMember, error) {
+	if days <= 0 || days > maxTimeSeriesDays {
+		days = 30
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	since := time.Now().AddDate(0, 0, -(days - 1))
+	return s.analyticsRepo.GetMostActiveMembers(ctx, channelID, since, limit)
+}
+
+// GetWorkspaceAnalytics builds the workspace dashboard from channel rows,
+// counters and daily rollups. Growth and most-joined cover the last days
+// days; channels idle for dormantDays or more are reported as dormant.
+func (s *ChannelService) GetWorkspaceAnalytics(ctx context.Context, workspaceID, granularity string, days, dormantDays int) (*models.WorkspaceAnalytics, error) {
+	switch granularity {
+	case "":
+		granularity = "day"
+	case "day", "week", "month":
+	default:
+		return nil, ErrInvalidGranularity
+	}
+	if days <= 0 || days > maxTimeSeriesDays {
+		days = 30
+	}
+	if dormantDays <= 0 {
+		dormantDays = 30
+	}
+
+	now := time.Now()
+	since := now.AddDate(0, 0, -(days - 1))
+
+	byType, err := s.analyticsRepo.CountChannelsByType(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	avgMembers, err := s.analyticsRepo.GetAverageMembers(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	growth, err := s.analyticsRepo.GetWorkspaceGrowth(ctx, workspaceID, granularity, since)
+	if err != nil {
+		return nil, err
+	}
+	dormant, dormantCount, err := s.analyticsRepo.ListDormantChannels(ctx, workspaceID, now.AddDate(0, 0, -dormantDays), maxDashboardChannels)
+	if err != nil {
+		return nil, err
+	}
+	mostJoined, err := s.analyticsRepo.GetMostJoinedChannels(ctx, workspaceID, since, maxDashboardChannels)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &models.WorkspaceAnalytics{
+		WorkspaceID:        workspaceID,
+		ChannelsByType:     byType,
+		AverageMembers:     avgMembers,
+		Growth:             growth,
+		DormantDays:        dormantDays,
+		DormantCount:       dormantCount,
+		DormantChannels:    dormant,
+		MostJoinedChannels: mostJoined,
+	}
+	for _, t := range byType {
+		analytics.TotalChannels += t.Total
+		analytics.ArchivedChannels += t.Archived
+	}
+	if analytics.TotalChannels > 0 {
+		analytics.ArchivedRatio = float64(analytics.ArchivedChannels) / float64(analytics.TotalChannels)
+	}
+
+	return analytics, nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// ── Announcement Acknowledgements ──
+
+const (
+	NotificationAckReminder = "announcement.ack_reminder"
+
+	DefaultAckReminderDelay = 24 * time.Hour
+
+	// ackReminderBatchSize bounds the announcements handled per sweep.
+	ackReminderBatchSize = 100
+)
+
+// AcknowledgeAnnouncement records that a member has read and acknowledged
+// the announcement. It is idempotent.
+func (s *ChannelService) AcknowledgeAnnouncement(ctx context.Context, channelID, announcementID, userID string) (*models.AnnouncementAck, error) {
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getAnnouncement(ctx, channelID, announcementID); err != nil {
+		return nil, err
+	}
+	return s.announcementRepo.Acknowledge(ctx, announcementID, userID, time.Now())
+}
+
+// GetAnnouncementAckReport lists who has acknowledged the announcement and
+// which current members are still outstanding.
+func (s *ChannelService) GetAnnouncementAckReport(ctx context.Context, channelID, announcementID, userID string) (*models.AnnouncementAckReport, error) {
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	ann, err := s.getAnnouncement(ctx, channelID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+
+	acks, err := s.announcementRepo.ListAcks(ctx, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	outstanding, err := s.announcementRepo.ListOutstanding(ctx, ann)
+	if err != nil {
+		return nil, err
+	}
+	if acks == nil {
+		acks = []*models.AnnouncementAck{}
+	}
+	if outstanding == nil {
+		outstanding = []string{}
+	}
+
+	return &models.AnnouncementAckReport{
+		AnnouncementID:    announcementID,
+		RequiresAck:       ann.RequiresAck,
+		AcknowledgedCount: len(acks),
+		OutstandingCount:  len(outstanding),
+		Acknowledged:      acks,
+		Outstanding:       outstanding,
+	}, nil
+}
+
+// RunAckReminders reminds members who have not acknowledged an announcement
+// requiring acknowledgement once delay has passed since it was posted. Each
+// announcement is reminded once. It runs every interval until ctx is
+// cancelled.
+func (s *ChannelService) RunAckReminders(ctx context.Context, interval, delay time.Duration) {
+	if delay <= 0 {
+		delay = DefaultAckReminderDelay
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sweepAckReminders(ctx, delay); err != nil && ctx.Err() == nil {
+			s.logger.WithError(err).Error("Announcement ack reminder sweep failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweepAckReminders handles up to one batch of due announcements; any
+// remainder is picked up on the next tick.
+func (s *ChannelService) sweepAckReminders(ctx context.Context, delay time.Duration) error {
+	now := time.Now()
+	anns, err := s.announcementRepo.ListDueAckReminders(ctx, now.Add(-delay), now, ackReminderBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, ann := range anns {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.sendAckReminder(ctx, ann); err != nil {
+			s.logger.WithError(err).WithFields(logrus.Fields{
+				"channel_id":      ann.ChannelID,
+				"announcement_id": ann.ID,
+			}).Error("Failed to send announcement ack reminder")
+		}
+	}
+
+	return nil
+}
+
+func (s *ChannelService) sendAckReminder(ctx context.Context, ann *models.ChannelAnnouncement) error {
+	claimed, err := s.announcementRepo.ClaimAckReminder(ctx, ann.ID, time.Now())
+	if err != nil || !claimed {
+		return err
+	}
+
+	outstanding, err := s.announcementRepo.ListOutstanding(ctx, ann)
+	if err != nil {
+		return err
+	}
+
+	s.publishNotification(ctx, models.NotificationEvent{
+		Type:      NotificationAckReminder,
+		Priority:  "normal",
+		ChannelID: ann.ChannelID,
+		EntityID:  ann.ID,
+		Title:     ann.Title,
+		Body:      "Please acknowledge this announcement.",
+		ActorID:   systemActorID,
+		CreatedAt: time.Now(),
+	}, outstanding)
+	return nil
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Announcements ──
+
+const (
+	TopicAnnouncementCreated = "channel.announcement.created"
+	TopicNotificationRequest = "notification.requested"
+
+	ActionAnnouncementCreated  = "announcement.created"
+	ActionAnnouncementUpdated  = "announcement.updated"
+	ActionAnnouncementDeleted  = "announcement.deleted"
+	ActionAnnouncementPinned   = "announcement.pinned"
+	ActionAnnouncementUnpinned = "announcement.unpinned"
+
+	PriorityNormal = "normal"
+	PriorityUrgent = "urgent"
+
+	NotificationUrgentAnnouncement = "announcement.urgent"
+
+	// notificationBatchSize caps the recipients carried by one event.
+	notificationBatchSize = 500
+)
+
+func (s *ChannelService) getAnnouncement(ctx context.Context, channelID, announcementID string) (*models.ChannelAnnouncement, error) {
+	ann, err := s.announcementRepo.GetByID(ctx, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	if ann == nil || ann.ChannelID != channelID {
+		return nil, ErrAnnouncementNotFound
+	}
+	return ann, nil
+}
+
+// CreateAnnouncement posts an announcement. Only channel admins and owners
+// may announce; urgent announcements also notify every member.
+func (s *ChannelService) CreateAnnouncement(ctx context.Context, channelID, userID string, req *models.CreateAnnouncementRequest) (*models.ChannelAnnouncement, error) {
+	if _, err := s.getChannel(ctx, channelID); err != nil {
+		return nil, err
+	}
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, ErrInvalidExpiry
+	}
+	priority := req.Priority
+	if priority == "" {
+		priority = PriorityNormal
+	}
+
+	ann := &models.ChannelAnnouncement{
+		ID:          uuid.New().String(),
+		ChannelID:   channelID,
+		Title:       strings.TrimSpace(req.Title),
+		Content:     req.Content,
+		Priority:    priority,
+		AuthorID:    userID,
+		IsPinned:    req.IsPinned,
+		RequiresAck: req.RequiresAck,
+		ExpiresAt:   req.ExpiresAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.announcementRepo.Create(ctx, ann); err != nil {
+		return nil, err
+	}
+
+	s.logActivity(ctx, channelID, userID, ActionAnnouncementCreated, &ann.ID, map[string]interface{}{
+		"title":    ann.Title,
+		"priority": ann.Priority,
+	})
+	s.publishEvent(ctx, TopicAnnouncementCreated, channelID, models.AnnouncementEvent{
+		AnnouncementID: ann.ID,
+		ChannelID:      channelID,
+		Title:          ann.Title,
+		Priority:       ann.Priority,
+		AuthorID:       userID,
+		CreatedAt:      now,
+	})
+	if ann.Priority == PriorityUrgent {
+		s.notifyUrgentAnnouncement(ctx, ann)
+	}
+
+	return ann, nil
+}
+
+// ListAnnouncements lists pinned announcements first, then newest first.
+// Expired announcements are left out unless includeExpired is set.
+func (s *ChannelService) ListAnnouncements(ctx context.Context, channelID, userID string, includeExpired bool) ([]*models.ChannelAnnouncement, error) {
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	if includeExpired {
+		return s.announcementRepo.ListByChannel(ctx, channelID)
+	}
+	return s.announcementRepo.ListActiveByChannel(ctx, channelID, time.Now())
+}
+
+func (s *ChannelService) GetAnnouncement(ctx context.Context, channelID, announcementID, userID string) (*models.ChannelAnnouncement, error) {
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	return s.getAnnouncement(ctx, channelID, announcementID)
+}
+
+// UpdateAnnouncement edits an announcement. Raising the priority to urgent
+// sends the urgent notification.
+func (s *ChannelService) UpdateAnnouncement(ctx context.Context, channelID, announcementID, userID string, req *models.UpdateAnnouncementRequest) (*models.ChannelAnnouncement, error) {
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	ann, err := s.getAnnouncement(ctx, channelID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, ErrInvalidExpiry
+	}
+
+	becameUrgent := req.Priority != nil && *req.Priority == PriorityUrgent && ann.Priority != PriorityUrgent
+	if req.Title != nil {
+		ann.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Content != nil {
+		ann.Content = *req.Content
+	}
+	if req.Priority != nil {
+		ann.Priority = *req.Priority
+	}
+	if req.RequiresAck != nil {
+		ann.RequiresAck = *req.RequiresAck
+	}
+	if req.ExpiresAt != nil {
+		ann.ExpiresAt = req.ExpiresAt
+	}
+	ann.UpdatedAt = now
+
+	if err := s.announcementRepo.Update(ctx, ann); err != nil {
+		return nil, err
+	}
+
+	s.logActivity(ctx, channelID, userID, ActionAnnouncementUpdated, &ann.ID, req)
+	if becameUrgent {
+		s.notifyUrgentAnnouncement(ctx, ann)
+	}
+
+	return ann, nil
+}
+
+func (s *ChannelService) DeleteAnnouncement(ctx context.Context, channelID, announcementID, userID string) error {
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return err
+	}
+	ann, err := s.getAnnouncement(ctx, channelID, announcementID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.announcementRepo.Delete(ctx, announcementID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, channelID, userID, ActionAnnouncementDeleted, &announcementID, map[string]string{"title": ann.Title})
+	return nil
+}
+
+func (s *ChannelService) SetAnnouncementPinned(ctx context.Context, channelID, announcementID, userID string, pinned bool) (*models.ChannelAnnouncement, error) {
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	ann, err := s.getAnnouncement(ctx, channelID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	if ann.IsPinned == pinned {
+		return ann, nil
+	}
+
+	if err := s.announcementRepo.TogglePin(ctx, announcementID, pinned); err != nil {
+		return nil, err
+	}
+	ann.IsPinned = pinned
+	ann.UpdatedAt = time.Now()
+
+	action := ActionAnnouncementUnpinned
+	if pinned {
+		action = ActionAnnouncementPinned
+	}
+	s.logActivity(ctx, channelID, userID, action, &announcementID, nil)
+
+	return ann, nil
+}
+
+// notifyUrgentAnnouncement sends a high-priority notification request for
+// every channel member except the author, in batches.
+func (s *ChannelService) notifyUrgentAnnouncement(ctx context.Context, ann *models.ChannelAnnouncement) {
+	userIDs, err := s.memberRepo.ListUserIDs(ctx, ann.ChannelID)
+	if err != nil {
+		s.logger.WithError(err).WithField("announcement_id", ann.ID).Error("Failed to list members for urgent announcement")
+		return
+	}
+
+	recipients := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id != ann.AuthorID {
+			recipients = append(recipients, id)
+		}
+	}
+
+	s.publishNotification(ctx, models.NotificationEvent{
+		Type:      NotificationUrgentAnnouncement,
+		Priority:  "high",
+		ChannelID: ann.ChannelID,
+		EntityID:  ann.ID,
+		Title:     ann.Title,
+		Body:      ann.Content,
+		ActorID:   ann.AuthorID,
+		CreatedAt: time.Now(),
+	}, recipients)
+}
+
+// publishNotification publishes event once per batch of recipients.
+func (s *ChannelService) publishNotification(ctx context.Context, event models.NotificationEvent, recipients []string) {
+	for start := 0; start < len(recipients); start += notificationBatchSize {
+		end := start + notificationBatchSize
+		if end > len(recipients) {
+			end = len(recipients)
+		}
+		event.RecipientIDs = recipients[start:end]
+		s.publishEvent(ctx, TopicNotificationRequest, event.ChannelID, event)
+	}
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Audit Export ──
+
+// ExportWorkspaceAudit streams the activity log and then the moderation log
+// of every channel in the workspace, each oldest first, calling fn once per
+// record. A zero from exports from the beginning and a zero to up to now.
+// Validation errors are returned before fn is first called.
+func (s *ChannelService) ExportWorkspaceAudit(ctx context.Context, workspaceID string, from, to time.Time, fn func(*models.AuditRecord) error) error {
+	if from.IsZero() {
+		from = time.Unix(0, 0)
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.Before(to) {
+		return ErrInvalidTimeRange
+	}
+
+	emit := func(record *models.AuditRecord) error {
+		if record.Details != nil && json.Valid([]byte(*record.Details)) {
+			record.DetailsJSON = json.RawMessage(*record.Details)
+		}
+		return fn(record)
+	}
+
+	if err := s.activityLogRepo.StreamByWorkspace(ctx, workspaceID, from, to, emit); err != nil {
+		return err
+	}
+	return s.moderationRepo.StreamByWorkspace(ctx, workspaceID, from, to, emit)
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// ── Auto-Archive ──
+
+const (
+	TopicChannelArchiveWarning = "channel.archive.warning"
+	TopicChannelArchived       = "channel.archived"
+
+	ActionChannelAutoArchived = "channel.auto_archived"
+
+	// systemActorID attributes automated actions in the activity log.
+	systemActorID = "system"
+)
+
+// RunAutoArchive runs the auto-archive sweep every interval until ctx is
+// cancelled.
+func (s *ChannelService) RunAutoArchive(ctx context.Context, interval time.Duration, warningDays int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sweepAutoArchive(ctx, warningDays); err != nil && ctx.Err() == nil {
+			s.logger.WithError(err).Error("Auto-archive sweep failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PreviewAutoArchive is the dry run of the sweep: it lists the channels in
+// the workspace that are due for archival or inside the warning window,
+// without changing anything.
+func (s *ChannelService) PreviewAutoArchive(ctx context.Context, workspaceID string, warningDays int) ([]*models.AutoArchiveCandidate, error) {
+	return s.listAutoArchiveCandidates(ctx, workspaceID, time.Now(), warningDays)
+}
+
+func (s *ChannelService) listAutoArchiveCandidates(ctx context.Context, workspaceID string, now time.Time, warningDays int) ([]*models.AutoArchiveCandidate, error) {
+	if warningDays < 0 {
+		warningDays = 0
+	}
+
+	candidates, err := s.settingsRepo.ListAutoArchiveCandidates(ctx, workspaceID, now, warningDays)
+	if err != nil {
+		return nil, err
+	}
+	// A channel is archived only once its owners have had warningDays of
+	// notice, even if it went idle long ago.
+	for _, c := range candidates {
+		c.ArchiveAt = c.LastActivityAt.AddDate(0, 0, c.AutoArchiveDays)
+		noticeFrom := now
+		if c.WarnedAt != nil {
+			noticeFrom = *c.WarnedAt
+		}
+		if notice := noticeFrom.AddDate(0, 0, warningDays); notice.After(c.ArchiveAt) {
+			c.ArchiveAt = notice
+		}
+		c.Due = c.WarnedAt != nil && !c.ArchiveAt.After(now)
+	}
+	if candidates == nil {
+		candidates = []*models.AutoArchiveCandidate{}
+	}
+	return candidates, nil
+}
+
+func (s *ChannelService) sweepAutoArchive(ctx context.Context, warningDays int) error {
+	candidates, err := s.listAutoArchiveCandidates(ctx, "", time.Now(), warningDays)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range candidates {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var err error
+		switch {
+		case c.Due:
+			err = s.autoArchiveChannel(ctx, c)
+		case c.WarnedAt == nil:
+			err = s.warnAutoArchive(ctx, c)
+		}
+		if err != nil {
+			s.logger.WithError(err).WithFields(logrus.Fields{
+				"channel_id": c.ChannelID,
+				"due":        c.Due,
+			}).Error("Failed to process auto-archive candidate")
+		}
+	}
+
+	return nil
+}
+
+func (s *ChannelService) warnAutoArchive(ctx context.Context, c *models.AutoArchiveCandidate) error {
+	claimed, err := s.settingsRepo.ClaimArchiveWarning(ctx, c.ChannelID, c.LastActivityAt)
+	if err != nil || !claimed {
+		return err
+	}
+
+	ownerIDs, err := s.memberRepo.ListUserIDsByRole(ctx, c.ChannelID, "owner", "admin")
+	if err != nil {
+		return err
+	}
+
+	s.publishEvent(ctx, TopicChannelArchiveWarning, c.ChannelID, &models.ChannelArchiveWarningEvent{
+		ChannelID:      c.ChannelID,
+		WorkspaceID:    c.WorkspaceID,
+		Name:           c.Name,
+		OwnerIDs:       ownerIDs,
+		LastActivityAt: c.LastActivityAt,
+		ArchiveAt:      c.ArchiveAt,
+	})
+	return nil
+}
+
+func (s *ChannelService) autoArchiveChannel(ctx context.Context, c *models.AutoArchiveCandidate) error {
+	if err := s.channelRepo.Archive(ctx, c.ChannelID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	s.logActivity(ctx, c.ChannelID, systemActorID, ActionChannelAutoArchived, nil, map[string]interface{}{
+		"auto_archive_days": c.AutoArchiveDays,
+		"last_activity_at":  c.LastActivityAt,
+	})
+	s.publishEvent(ctx, TopicChannelArchived, c.ChannelID, &models.ChannelArchivedEvent{
+		ChannelID:   c.ChannelID,
+		WorkspaceID: c.WorkspaceID,
+		Reason:      "inactive",
+		ArchivedAt:  now,
+	})
+
+	s.logger.WithFields(logrus.Fields{
+		"channel_id":        c.ChannelID,
+		"auto_archive_days": c.AutoArchiveDays,
+	}).Info("Auto-archived inactive channel")
+	return nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Bookmarks ──
+
+// validateBookmarkURL accepts absolute http(s) URLs with a host.
+func validateBookmarkURL(raw string) error {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	return nil
+}
+
+func (s *ChannelService) getOwnBookmark(ctx context.Context, channelID, bookmarkID, userID string) (*models.ChannelBookmark, error) {
+	bookmark, err := s.bookmarkRepo.GetByID(ctx, bookmarkID)
+	if err != nil {
+		return nil, err
+	}
+	if bookmark == nil || bookmark.ChannelID != channelID || bookmark.UserID != userID {
+		return nil, ErrBookmarkNotFound
+	}
+	return bookmark, nil
+}
+
+// CreateBookmark saves a URL or an in-channel entity (thread, poll or
+// announcement) for the user, up to the channel's max_bookmarks. Entity
+// bookmarks default their title to the entity's current title.
+func (s *ChannelService) CreateBookmark(ctx context.Context, channelID, userID string, req *models.CreateBookmarkRequest) (*models.ChannelBookmark, error) {
+	isURL := req.URL != nil && *req.URL != ""
+	isEntity := req.EntityType != nil && req.EntityID != nil && *req.EntityID != ""
+	if isURL == isEntity {
+		return nil, ErrInvalidBookmarkTarget
+	}
+
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	bookmark := &models.ChannelBookmark{
+		ID:        uuid.New().String(),
+		ChannelID: channelID,
+		UserID:    userID,
+	}
+
+	if isURL {
+		if err := validateBookmarkURL(*req.URL); err != nil {
+			return nil, err
+		}
+		link := strings.TrimSpace(*req.URL)
+		bookmark.URL = &link
+		if title == "" {
+			title = link
+		}
+	} else {
+		entityChannel, entityTitle, found, err := s.bookmarkRepo.GetEntity(ctx, *req.EntityType, *req.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		if !found || entityChannel != channelID {
+			return nil, ErrBookmarkEntityNotFound
+		}
+		bookmark.EntityType = req.EntityType
+		bookmark.EntityID = req.EntityID
+		if title == "" {
+			title = entityTitle
+		}
+		if title == "" {
+			title = *req.EntityType
+		}
+	}
+	if runes := []rune(title); len(runes) > 255 {
+		title = string(runes[:255])
+	}
+	bookmark.Title = title
+
+	settings, err := s.getChannelSettings(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.bookmarkRepo.CountByUser(ctx, channelID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= settings.MaxBookmarks {
+		return nil, ErrBookmarkLimitReached
+	}
+
+	maxPos, err := s.bookmarkRepo.GetMaxPosition(ctx, channelID, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	bookmark.Position = maxPos + 1
+	bookmark.CreatedAt = now
+	bookmark.UpdatedAt = now
+
+	if err := s.bookmarkRepo.Create(ctx, bookmark); err != nil {
+		return nil, err
+	}
+
+	if bookmark.URL != nil {
+		s.warmLinkPreviews(ctx, channelID, *bookmark.URL)
+	}
+
+	return bookmark, nil
+}
+
+func (s *ChannelService) ListBookmarks(ctx context.Context, channelID, userID string) ([]*models.BookmarkWithEntity, error) {
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	bookmarks, err := s.bookmarkRepo.ListWithEntities(ctx, channelID, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.attachBookmarkPreviews(ctx, channelID, bookmarks)
+	return bookmarks, nil
+}
+
+// UpdateBookmark renames a bookmark or, for URL bookmarks, changes the URL.
+func (s *ChannelService) UpdateBookmark(ctx context.Context, channelID, bookmarkID, userID string, req *models.UpdateBookmarkRequest) (*models.ChannelBookmark, error) {
+	bookmark, err := s.getOwnBookmark(ctx, channelID, bookmarkID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, ErrBookmarkTitleRequired
+		}
+		bookmark.Title = title
+	}
+	if req.URL != nil {
+		if bookmark.URL == nil {
+			return nil, ErrInvalidBookmarkTarget
+		}
+		if err := validateBookmarkURL(*req.URL); err != nil {
+			return nil, err
+		}
+		link := strings.TrimSpace(*req.URL)
+		bookmark.URL = &link
+	}
+	bookmark.UpdatedAt = time.Now()
+
+	if err := s.bookmarkRepo.Update(ctx, bookmark); err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		s.warmLinkPreviews(ctx, channelID, *bookmark.URL)
+	}
+
+	return bookmark, nil
+}
+
+func (s *ChannelService) DeleteBookmark(ctx context.Context, channelID, bookmarkID, userID string) error {
+	if _, err := s.getOwnBookmark(ctx, channelID, bookmarkID, userID); err != nil {
+		return err
+	}
+	return s.bookmarkRepo.Delete(ctx, bookmarkID)
+}
+
+func (s *ChannelService) ReorderBookmarks(ctx context.Context, channelID, userID string, req *models.ReorderBookmarksRequest) ([]*models.BookmarkWithEntity, error) {
+	ok, err := s.bookmarkRepo.Reorder(ctx, channelID, userID, req.BookmarkIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidBookmarkOrder
+	}
+	bookmarks, err := s.bookmarkRepo.ListWithEntities(ctx, channelID, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.attachBookmarkPreviews(ctx, channelID, bookmarks)
+	return bookmarks, nil
+}
@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/db"
 	"github.com/quckapp/channel-service/internal/models"
 	"github.com/quckapp/channel-service/internal/repository"
+	"github.com/quckapp/channel-service/internal/unfurl"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
@@ -22,34 +27,149 @@ var (
 	ErrChannelTemplateNotFound  = errors.New("channel template not found")
 	ErrNotFollowing             = errors.New("not following this channel")
 	ErrScheduledTimeInPast      = errors.New("scheduled time must be in the future")
+	ErrReactionsDisabled        = errors.New("reactions are disabled in this channel")
+	ErrCustomEmojiDisabled      = errors.New("custom emoji are disabled in this channel")
+	ErrTooManyMessageIDs        = errors.New("too many message IDs")
+	ErrChannelNotFound          = errors.New("channel not found")
+	ErrForbidden                = errors.New("insufficient permissions")
+	ErrInvalidEmoji             = errors.New("emoji is not a unicode emoji or registered custom emoji")
+	ErrInvalidEmojiShortcode    = errors.New("invalid emoji shortcode")
+	ErrEmojiShortcodeTaken      = errors.New("emoji shortcode already in use")
+	ErrCustomEmojiNotFound      = errors.New("custom emoji not found")
+	ErrNotChannelMember         = errors.New("user is not a member of this channel")
+	ErrMessageNotFound          = errors.New("message not found")
+	ErrInvalidGranularity       = errors.New("granularity must be day, week or month")
+	ErrInvalidTimeRange         = errors.New("invalid time range")
+	ErrChannelFull              = errors.New("channel has reached its member limit")
+	ErrChannelArchived          = errors.New("channel is archived")
+	ErrAlreadyMember            = errors.New("user is already a member of this channel")
+	ErrMemberNotFound           = errors.New("member not found")
+	ErrInviteNotFound           = errors.New("invite not found")
+	ErrInviteExhausted          = errors.New("invite has expired or reached its maximum uses")
+	ErrNotWaitlisted            = errors.New("user is not on the waitlist")
+	ErrStarLimitReached         = errors.New("starred channel limit reached")
+	ErrAlreadyStarred           = errors.New("channel is already starred")
+	ErrNotStarred               = errors.New("channel is not starred")
+	ErrInvalidStarOrder         = errors.New("order must list every starred channel exactly once")
+	ErrSectionNotFound          = errors.New("section not found")
+	ErrInvalidSectionOrder      = errors.New("order must list every section exactly once")
+	ErrWorkspaceMismatch        = errors.New("channel belongs to a different workspace")
+	ErrBookmarkNotFound         = errors.New("bookmark not found")
+	ErrBookmarkLimitReached     = errors.New("bookmark limit reached for this channel")
+	ErrInvalidBookmarkTarget    = errors.New("bookmark needs either a url or an entity_type and entity_id")
+	ErrInvalidURL               = errors.New("url must be an absolute http or https URL")
+	ErrBookmarkEntityNotFound   = errors.New("bookmarked entity not found in this channel")
+	ErrInvalidBookmarkOrder     = errors.New("order must list every bookmark exactly once")
+	ErrBookmarkTitleRequired    = errors.New("bookmark title must not be blank")
+	ErrLinkPreviewsDisabled     = errors.New("link previews are disabled in this channel")
+	ErrLinkPreviewUnavailable   = errors.New("link preview could not be fetched")
+	ErrAnnouncementNotFound     = errors.New("announcement not found")
+	ErrInvalidExpiry            = errors.New("expires_at must be in the future")
+	ErrTopicHistoryNotFound     = errors.New("topic history entry not found")
+	ErrChannelNameTaken         = errors.New("channel name already in use")
+	ErrInvalidCursor            = errors.New("invalid cursor")
+	ErrNotInVoiceChannel        = errors.New("user is not connected to this voice channel")
+	ErrVoiceStageAudience       = errors.New("only speakers may unmute in stage mode")
+	ErrVoiceStageModeOff        = errors.New("stage mode is not enabled in this channel")
+	ErrInvalidTemplate          = errors.New("template defaults are malformed")
+	ErrTemplateVersionNotFound  = errors.New("template version not found")
+	ErrTemplateSourceType       = errors.New("only public and private channels can be saved as templates")
+	ErrInvalidTemplateSort      = errors.New("sort must be popular or recent")
+	ErrChannelNameRequired      = errors.New("channel name must not be blank")
 )
 
 type ChannelService struct {
+	channelRepo          *repository.ChannelRepository
+	memberRepo           *repository.MemberRepository
 	pollRepo             *repository.PollRepository
 	scheduledMessageRepo *repository.ScheduledMessageRepository
 	channelLinkRepo      *repository.ChannelLinkRepository
 	tabRepo              *repository.TabRepository
 	followerRepo         *repository.FollowerRepository
 	templateRepo         *repository.TemplateRepository
+	reactionRepo         *repository.ReactionRepository
+	settingsRepo         *repository.SettingsRepository
+	emojiRepo            *repository.EmojiRepository
+	readReceiptRepo      *repository.ReadReceiptRepository
+	messageRepo          *repository.MessageRepository
+	analyticsRepo        *repository.AnalyticsRepository
+	activityLogRepo      *repository.ActivityLogRepository
+	inviteRepo           *repository.InviteRepository
+	starredRepo          *repository.StarredRepository
+	sectionRepo          *repository.SectionRepository
+	bookmarkRepo         *repository.BookmarkRepository
+	announcementRepo     *repository.AnnouncementRepository
+	topicHistoryRepo     *repository.TopicHistoryRepository
+	moderationRepo       *repository.ModerationRepository
+	voiceRepo            *repository.VoiceRepository
+	permissionRepo       *repository.PermissionRepository
+	pinRepo              *repository.PinRepository
+	unfurler             *unfurl.Unfurler
+	redis                *redis.Client
+	kafka                *db.KafkaProducer
 	logger               *logrus.Logger
 }
 
 func NewChannelService(
+	channelRepo *repository.ChannelRepository,
+	memberRepo *repository.MemberRepository,
 	pollRepo *repository.PollRepository,
 	scheduledMessageRepo *repository.ScheduledMessageRepository,
 	channelLinkRepo *repository.ChannelLinkRepository,
 	tabRepo *repository.TabRepository,
 	followerRepo *repository.FollowerRepository,
 	templateRepo *repository.TemplateRepository,
+	reactionRepo *repository.ReactionRepository,
+	settingsRepo *repository.SettingsRepository,
+	emojiRepo *repository.EmojiRepository,
+	readReceiptRepo *repository.ReadReceiptRepository,
+	messageRepo *repository.MessageRepository,
+	analyticsRepo *repository.AnalyticsRepository,
+	activityLogRepo *repository.ActivityLogRepository,
+	inviteRepo *repository.InviteRepository,
+	starredRepo *repository.StarredRepository,
+	sectionRepo *repository.SectionRepository,
+	bookmarkRepo *repository.BookmarkRepository,
+	announcementRepo *repository.AnnouncementRepository,
+	topicHistoryRepo *repository.TopicHistoryRepository,
+	moderationRepo *repository.ModerationRepository,
+	voiceRepo *repository.VoiceRepository,
+	permissionRepo *repository.PermissionRepository,
+	pinRepo *repository.PinRepository,
+	unfurler *unfurl.Unfurler,
+	redisClient *redis.Client,
+	kafkaProducer *db.KafkaProducer,
 	logger *logrus.Logger,
 ) *ChannelService {
 	return &ChannelService{
+		channelRepo:          channelRepo,
+		memberRepo:           memberRepo,
 		pollRepo:             pollRepo,
 		scheduledMessageRepo: scheduledMessageRepo,
 		channelLinkRepo:      channelLinkRepo,
 		tabRepo:              tabRepo,
 		followerRepo:         followerRepo,
 		templateRepo:         templateRepo,
+		reactionRepo:         reactionRepo,
+		settingsRepo:         settingsRepo,
+		emojiRepo:            emojiRepo,
+		readReceiptRepo:      readReceiptRepo,
+		messageRepo:          messageRepo,
+		analyticsRepo:        analyticsRepo,
+		activityLogRepo:      activityLogRepo,
+		inviteRepo:           inviteRepo,
+		starredRepo:          starredRepo,
+		sectionRepo:          sectionRepo,
+		bookmarkRepo:         bookmarkRepo,
+		announcementRepo:     announcementRepo,
+		topicHistoryRepo:     topicHistoryRepo,
+		moderationRepo:       moderationRepo,
+		voiceRepo:            voiceRepo,
+		permissionRepo:       permissionRepo,
+		pinRepo:              pinRepo,
+		unfurler:             unfurler,
+		redis:                redisClient,
+		kafka:                kafkaProducer,
 		logger:               logger,
 	}
 }
@@ -89,6 +209,12 @@ func (s *ChannelService) CreatePoll(ctx context.Context, channelID, userID strin
 		options = append(options, *option)
 	}
 
+	s.logActivity(ctx, channelID, userID, ActionPollCreated, &poll.ID, map[string]interface{}{
+		"question":     poll.Question,
+		"options":      len(options),
+		"is_anonymous": poll.IsAnonymous,
+	})
+
 	return &models.PollWithOptions{
 		ChannelPoll: *poll,
 		Options:     options,
@@ -153,6 +279,13 @@ func (s *ChannelService) VotePoll(ctx context.Context, pollID, userID string, re
 		}
 	}
 
+	// Choices on anonymous polls stay out of the audit trail.
+	var details interface{}
+	if !poll.IsAnonymous {
+		details = map[string]interface{}{"option_ids": req.OptionIDs}
+	}
+	s.logActivity(ctx, poll.ChannelID, userID, ActionPollVoted, &pollID, details)
+
 	return nil
 }
 
@@ -169,7 +302,12 @@ func (s *ChannelService) ClosePoll(ctx context.Context, pollID, userID string) e
 	}
 
 	now := time.Now()
-	return s.pollRepo.ClosePoll(ctx, pollID, now)
+	if err := s.pollRepo.ClosePoll(ctx, pollID, now); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, poll.ChannelID, userID, ActionPollClosed, &pollID, nil)
+	return nil
 }
 
 func (s *ChannelService) GetPollResults(ctx context.Context, pollID string) (*models.PollResults, error) {
@@ -221,6 +359,10 @@ func (s *ChannelService) ScheduleMessage(ctx context.Context, channelID, userID
 		return nil, err
 	}
 
+	s.logActivity(ctx, channelID, userID, ActionScheduledMessageCreated, &msg.ID, map[string]interface{}{
+		"scheduled_at": msg.ScheduledAt,
+	})
+
 	return msg, nil
 }
 
@@ -262,6 +404,11 @@ func (s *ChannelService) UpdateScheduledMessage(ctx context.Context, messageID,
 		return nil, err
 	}
 
+	s.logActivity(ctx, msg.ChannelID, userID, ActionScheduledMessageUpdated, &msg.ID, map[string]interface{}{
+		"content_changed": req.Content != nil,
+		"scheduled_at":    msg.ScheduledAt,
+	})
+
 	return msg, nil
 }
 
@@ -274,7 +421,12 @@ func (s *ChannelService) CancelScheduledMessage(ctx context.Context, messageID,
 		return ErrScheduledMessageNotFound
 	}
 
-	return s.scheduledMessageRepo.Cancel(ctx, messageID)
+	if err := s.scheduledMessageRepo.Cancel(ctx, messageID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, msg.ChannelID, userID, ActionScheduledMessageCancelled, &messageID, nil)
+	return nil
 }
 
 // ── Channel Links ──
@@ -296,6 +448,11 @@ func (s *ChannelService) CreateChannelLink(ctx context.Context, channelID, userI
 		return nil, err
 	}
 
+	s.logActivity(ctx, channelID, userID, ActionLinkCreated, &link.ID, map[string]interface{}{
+		"target_channel_id": link.TargetChannelID,
+		"link_type":         link.LinkType,
+	})
+
 	return link, nil
 }
 
@@ -323,7 +480,14 @@ func (s *ChannelService) DeleteChannelLink(ctx context.Context, linkID, userID s
 		return ErrChannelLinkNotFound
 	}
 
-	return s.channelLinkRepo.Delete(ctx, linkID)
+	if err := s.channelLinkRepo.Delete(ctx, linkID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, link.SourceChannelID, userID, ActionLinkDeleted, &linkID, map[string]interface{}{
+		"target_channel_id": link.TargetChannelID,
+	})
+	return nil
 }
 
 // ── Channel Tabs ──
@@ -351,11 +515,23 @@ func (s *ChannelService) AddTab(ctx context.Context, channelID, userID string, r
 		return nil, err
 	}
 
+	s.logActivity(ctx, channelID, userID, ActionTabAdded, &tab.ID, map[string]interface{}{
+		"name":     tab.Name,
+		"tab_type": tab.TabType,
+	})
+
+	s.warmLinkPreviews(ctx, channelID, linksTabURLs(tab)...)
+
 	return tab, nil
 }
 
 func (s *ChannelService) ListTabs(ctx context.Context, channelID string) ([]*models.ChannelTab, error) {
-	return s.tabRepo.ListByChannel(ctx, channelID)
+	tabs, err := s.tabRepo.ListByChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	s.attachTabPreviews(ctx, channelID, tabs)
+	return tabs, nil
 }
 
 func (s *ChannelService) UpdateTab(ctx context.Context, tabID, userID string, req *models.UpdateTabRequest) (*models.ChannelTab, error) {
@@ -378,6 +554,15 @@ func (s *ChannelService) UpdateTab(ctx context.Context, tabID, userID string, re
 		return nil, err
 	}
 
+	s.logActivity(ctx, tab.ChannelID, userID, ActionTabUpdated, &tab.ID, map[string]interface{}{
+		"name":           tab.Name,
+		"config_changed": req.Config != nil,
+	})
+
+	if req.Config != nil {
+		s.warmLinkPreviews(ctx, tab.ChannelID, linksTabURLs(tab)...)
+	}
+
 	return tab, nil
 }
 
@@ -390,11 +575,21 @@ func (s *ChannelService) RemoveTab(ctx context.Context, tabID, userID string) er
 		return ErrTabNotFound
 	}
 
-	return s.tabRepo.Delete(ctx, tabID)
+	if err := s.tabRepo.Delete(ctx, tabID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, tab.ChannelID, userID, ActionTabRemoved, &tabID, map[string]interface{}{"name": tab.Name})
+	return nil
 }
 
 func (s *ChannelService) ReorderTabs(ctx context.Context, channelID, userID string, req *models.ReorderTabsRequest) error {
-	return s.tabRepo.UpdatePositions(ctx, channelID, req.TabIDs)
+	if err := s.tabRepo.UpdatePositions(ctx, channelID, req.TabIDs); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, channelID, userID, ActionTabsReordered, nil, map[string]interface{}{"tab_ids": req.TabIDs})
+	return nil
 }
 
 // ── Channel Followers ──
@@ -419,6 +614,8 @@ func (s *ChannelService) FollowChannel(ctx context.Context, channelID, userID st
 		return nil, err
 	}
 
+	s.logActivity(ctx, channelID, userID, ActionChannelFollowed, nil, nil)
+
 	return follower, nil
 }
 
@@ -431,7 +628,12 @@ func (s *ChannelService) UnfollowChannel(ctx context.Context, channelID, userID
 		return ErrNotFollowing
 	}
 
-	return s.followerRepo.Delete(ctx, channelID, userID)
+	if err := s.followerRepo.Delete(ctx, channelID, userID); err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, channelID, userID, ActionChannelUnfollowed, nil, nil)
+	return nil
 }
 
 func (s *ChannelService) ListFollowers(ctx context.Context, channelID string) ([]*models.ChannelFollower, error) {
@@ -442,57 +644,88 @@ func (s *ChannelService) CheckFollowing(ctx context.Context, channelID, userID s
 	return s.followerRepo.IsFollowing(ctx, channelID, userID)
 }
 
-// ── Channel Templates ──
+// ── Helpers ──
 
-func (s *ChannelService) CreateTemplate(ctx context.Context, channelID, userID string, req *models.CreateTemplateRequest) (*models.ChannelTemplate, error) {
-	now := time.Now()
-	tmpl := &models.ChannelTemplate{
-		ID:          uuid.New().String(),
-		Name:        req.Name,
-		Description: req.Description,
-		CreatedBy:   userID,
-		ChannelType: "public",
-		IsPublic:    req.IsPublic,
-		UseCount:    0,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+func (s *ChannelService) requireMember(ctx context.Context, channelID, userID string) error {
+	isMember, err := s.memberRepo.IsMember(ctx, channelID, userID)
+	if err != nil {
+		return err
 	}
+	if !isMember {
+		return ErrNotChannelMember
+	}
+	return nil
+}
 
-	if err := s.templateRepo.Create(ctx, tmpl); err != nil {
-		return nil, err
+// requireChannelAdmin returns ErrForbidden unless userID is an owner or
+// admin of the channel.
+func (s *ChannelService) requireChannelAdmin(ctx context.Context, channelID, userID string) error {
+	role, err := s.memberRepo.GetRole(ctx, channelID, userID)
+	if err == sql.ErrNoRows {
+		return ErrNotChannelMember
 	}
+	if err != nil {
+		return err
+	}
+	if role != "owner" && role != "admin" {
+		return ErrForbidden
+	}
+	return nil
+}
 
-	return tmpl, nil
+// WorkspaceScope is the workspace the caller's token was issued for and
+// their role in it.
+type WorkspaceScope struct {
+	WorkspaceID string
+	Role        string
 }
 
-func (s *ChannelService) ListTemplates(ctx context.Context) ([]*models.ChannelTemplate, error) {
-	return s.templateRepo.List(ctx)
+// IsAdminOf reports whether the caller is an owner or admin of workspaceID.
+func (w WorkspaceScope) IsAdminOf(workspaceID string) bool {
+	return workspaceID != "" && w.WorkspaceID == workspaceID && (w.Role == "owner" || w.Role == "admin")
 }
 
-func (s *ChannelService) ApplyTemplate(ctx context.Context, templateID, userID string, req *models.ApplyTemplateRequest) (*models.ChannelTemplate, error) {
-	tmpl, err := s.templateRepo.GetByID(ctx, templateID)
+func (s *ChannelService) getChannel(ctx context.Context, channelID string) (*models.Channel, error) {
+	channel, err := s.channelRepo.GetByID(ctx, channelID)
 	if err != nil {
 		return nil, err
 	}
-	if tmpl == nil {
-		return nil, ErrChannelTemplateNotFound
+	if channel == nil {
+		return nil, ErrChannelNotFound
 	}
+	return channel, nil
+}
 
-	if err := s.templateRepo.IncrementUseCount(ctx, templateID); err != nil {
-		return nil, err
+// publishEvent sends an event if Kafka is configured. Events are
+// fire-and-forget, so failures are logged and not returned.
+func (s *ChannelService) publishEvent(ctx context.Context, topic, key string, payload interface{}) {
+	if s.kafka == nil {
+		return
+	}
+	if err := s.kafka.Publish(ctx, topic, key, payload); err != nil {
+		s.logger.WithError(err).WithField("topic", topic).Warn("Failed to publish event")
 	}
-
-	return tmpl, nil
 }
 
-func (s *ChannelService) DeleteTemplate(ctx context.Context, templateID, userID string) error {
-	tmpl, err := s.templateRepo.GetByID(ctx, templateID)
-	if err != nil {
-		return err
+// logActivity appends an entry to the channel activity log with details
+// encoded as JSON. Logging failures never fail the audited operation.
+func (s *ChannelService) logActivity(ctx context.Context, channelID, userID, action string, targetID *string, details interface{}) {
+	entry := &models.ChannelActivityLog{
+		ID:        uuid.New().String(),
+		ChannelID: channelID,
+		UserID:    userID,
+		Action:    action,
+		TargetID:  targetID,
+		CreatedAt: time.Now(),
+	}
+	if details != nil {
+		data, err := json.Marshal(details)
+		if err == nil {
+			encoded := string(data)
+			entry.Details = &encoded
+		}
 	}
-	if tmpl == nil {
-		return ErrChannelTemplateNotFound
+	if err := s.activityLogRepo.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("action", action).Warn("Failed to write activity log")
 	}
-
-	return s.templateRepo.Delete(ctx, templateID)
 }
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/quckapp/channel-service/internal/repository"
+)
+
+// ── Custom Emoji ──
+
+const defaultFrequentEmojiLimit = 20
+
+var emojiShortcodePattern = regexp.MustCompile(`^[a-z0-9_+\-]{2,48}$`)
+
+// isUnicodeEmoji reports whether s is made up solely of emoji code points and
+// the modifiers used to compose them (ZWJ sequences, skin tones, variation
+// selectors, keycaps and subdivision flag tags).
+func isUnicodeEmoji(s string) bool {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return false
+	}
+
+	keycap := strings.ContainsRune(s, 0x20E3)
+	hasBase := false
+	for i, r := range runes {
+		switch {
+		case isEmojiBase(r):
+			hasBase = true
+		case keycap && (r == '#' || r == '*' || (r >= '0' && r <= '9')):
+			hasBase = true
+		case r == 0x200D, r == 0xFE0E, r == 0xFE0F, r == 0x20E3,
+			r >= 0x1F3FB && r <= 0x1F3FF,
+			r >= 0xE0020 && r <= 0xE007F:
+			if i == 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return hasBase
+}
+
+func isEmojiBase(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	case r >= 0x2600 && r <= 0x27BF:
+		return true
+	case r >= 0x2300 && r <= 0x23FF:
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF:
+		return true
+	case r >= 0x2190 && r <= 0x21FF:
+		return true
+	case r >= 0x25A0 && r <= 0x25FF:
+		return true
+	}
+	switch r {
+	case 0x00A9, 0x00AE, 0x203C, 0x2049, 0x2122, 0x2139, 0x24C2, 0x2934, 0x2935, 0x3030, 0x303D, 0x3297, 0x3299:
+		return true
+	}
+	return false
+}
+
+// resolveReactionEmoji validates a reaction against Unicode emoji and the
+// workspace registry. Custom emoji are returned in their canonical
+// ":shortcode:" form so aliases aggregate under a single reaction.
+func (s *ChannelService) resolveReactionEmoji(ctx context.Context, workspaceID, emoji string) (string, *models.CustomEmoji, error) {
+	if !isCustomEmoji(emoji) {
+		if !isUnicodeEmoji(emoji) {
+			return "", nil, ErrInvalidEmoji
+		}
+		return emoji, nil, nil
+	}
+
+	custom, err := s.emojiRepo.GetByName(ctx, workspaceID, strings.Trim(emoji, ":"))
+	if err != nil {
+		return "", nil, err
+	}
+	if custom == nil {
+		return "", nil, ErrInvalidEmoji
+	}
+	return ":" + custom.Shortcode + ":", custom, nil
+}
+
+func (s *ChannelService) recordEmojiUsage(ctx context.Context, workspaceID, userID, emoji string, custom *models.CustomEmoji) {
+	if err := s.emojiRepo.RecordUsage(ctx, workspaceID, userID, emoji); err != nil {
+		s.logger.WithError(err).Warn("Failed to record emoji usage")
+	}
+	if custom != nil {
+		if err := s.emojiRepo.IncrementUsageCount(ctx, custom.ID); err != nil {
+			s.logger.WithError(err).Warn("Failed to increment custom emoji usage count")
+		}
+	}
+}
+
+func (s *ChannelService) CreateCustomEmoji(ctx context.Context, workspaceID, userID string, req *models.CreateCustomEmojiRequest) (*models.CustomEmoji, error) {
+	shortcode := strings.ToLower(strings.Trim(req.Shortcode, ":"))
+	if !emojiShortcodePattern.MatchString(shortcode) {
+		return nil, ErrInvalidEmojiShortcode
+	}
+
+	names := []string{shortcode}
+	aliases := []string{}
+	seen := map[string]bool{shortcode: true}
+	for _, alias := range req.Aliases {
+		alias = strings.ToLower(strings.Trim(alias, ":"))
+		if !emojiShortcodePattern.MatchString(alias) {
+			return nil, ErrInvalidEmojiShortcode
+		}
+		if seen[alias] {
+			continue
+		}
+		seen[alias] = true
+		aliases = append(aliases, alias)
+		names = append(names, alias)
+	}
+
+	taken, err := s.emojiRepo.NamesTaken(ctx, workspaceID, names)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, ErrEmojiShortcodeTaken
+	}
+
+	now := time.Now()
+	emoji := &models.CustomEmoji{
+		ID:          uuid.New().String(),
+		WorkspaceID: workspaceID,
+		Shortcode:   shortcode,
+		ImageURL:    req.ImageURL,
+		Aliases:     aliases,
+		CreatedBy:   userID,
+		UsageCount:  0,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.emojiRepo.Create(ctx, emoji); err != nil {
+		// NamesTaken above is only a fast path; a concurrent create can
+		// still claim one of the names first.
+		if repository.IsEmojiNameTaken(err) {
+			return nil, ErrEmojiShortcodeTaken
+		}
+		return nil, err
+	}
+
+	return emoji, nil
+}
+
+func (s *ChannelService) ListCustomEmoji(ctx context.Context, workspaceID string) ([]*models.CustomEmoji, error) {
+	return s.emojiRepo.ListByWorkspace(ctx, workspaceID)
+}
+
+func (s *ChannelService) GetCustomEmoji(ctx context.Context, workspaceID, name string) (*models.CustomEmoji, error) {
+	emoji, err := s.emojiRepo.GetByName(ctx, workspaceID, strings.ToLower(strings.Trim(name, ":")))
+	if err != nil {
+		return nil, err
+	}
+	if emoji == nil {
+		return nil, ErrCustomEmojiNotFound
+	}
+
+	emoji.Aliases, err = s.emojiRepo.ListAliases(ctx, emoji.ID)
+	if err != nil {
+		return nil, err
+	}
+	return emoji, nil
+}
+
+// DeleteCustomEmoji removes an emoji from the workspace registry. Callers
+// are workspace admins, so it need not be their own.
+func (s *ChannelService) DeleteCustomEmoji(ctx context.Context, workspaceID, emojiID string) error {
+	emoji, err := s.emojiRepo.GetByID(ctx, emojiID)
+	if err != nil {
+		return err
+	}
+	if emoji == nil || emoji.WorkspaceID != workspaceID {
+		return ErrCustomEmojiNotFound
+	}
+
+	return s.emojiRepo.Delete(ctx, emojiID)
+}
+
+func (s *ChannelService) ListFrequentEmoji(ctx context.Context, workspaceID, userID string, limit int) ([]*models.EmojiUsage, error) {
+	if limit <= 0 || limit > 100 {
+		limit = defaultFrequentEmojiLimit
+	}
+	return s.emojiRepo.ListFrequent(ctx, workspaceID, userID, limit)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/quckapp/channel-service/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// ── Member Events ──
+
+const (
+	TopicMemberJoined = "channel.member.joined"
+	TopicMemberLeft   = "channel.member.left"
+)
+
+// HandleEvent routes a consumed Kafka message to its handler by topic. An
+// error means the event should be redelivered.
+func (s *ChannelService) HandleEvent(ctx context.Context, topic string, key, value []byte) error {
+	switch topic {
+	case TopicMemberJoined, TopicMemberLeft:
+		return s.HandleMemberEvent(ctx, topic, key, value)
+	default:
+		return s.HandleMessageEvent(ctx, topic, key, value)
+	}
+}
+
+// HandleMemberEvent rolls membership changes into the daily analytics.
+// Events are deduplicated by event ID. Malformed events and events the
+// database rejects outright are discarded; other processing errors are
+// returned so the event is retried.
+func (s *ChannelService) HandleMemberEvent(ctx context.Context, topic string, key, value []byte) error {
+	var event models.MemberEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		s.logger.WithError(err).WithField("topic", topic).Warn("Discarding malformed member event")
+		return nil
+	}
+	if event.Type == "" {
+		event.Type = topic
+	}
+	if event.EventID == "" || event.ChannelID == "" {
+		s.logger.WithField("topic", topic).Warn("Discarding member event without event or channel ID")
+		return nil
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	var metric string
+	switch event.Type {
+	case TopicMemberJoined:
+		metric = repository.MetricJoins
+	case TopicMemberLeft:
+		metric = repository.MetricLeaves
+	default:
+		return nil
+	}
+
+	if _, err := s.analyticsRepo.RecordMembershipEvent(ctx, event.EventID, event.ChannelID, metric, event.OccurredAt); err != nil {
+		log := s.logger.WithError(err).WithFields(logrus.Fields{
+			"type":       event.Type,
+			"event_id":   event.EventID,
+			"channel_id": event.ChannelID,
+		})
+		if repository.IsPermanent(err) {
+			log.Warn("Discarding member event that cannot be applied")
+			return nil
+		}
+		log.Error("Failed to handle member event")
+		return err
+	}
+	return nil
+}
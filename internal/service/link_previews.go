@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Link Previews ──
+
+const (
+	// warmPreviewTimeout bounds a background warm-up of one batch of URLs.
+	warmPreviewTimeout = 30 * time.Second
+	maxWarmPreviewURLs = 20
+)
+
+// linksTabConfig is the config of a "links" tab: either a single url or a
+// list of links.
+type linksTabConfig struct {
+	URL   string `json:"url"`
+	Links []struct {
+		URL string `json:"url"`
+	} `json:"links"`
+}
+
+func linksTabURLs(tab *models.ChannelTab) []string {
+	if tab.TabType != "links" || tab.Config == nil {
+		return nil
+	}
+	var cfg linksTabConfig
+	if err := json.Unmarshal([]byte(*tab.Config), &cfg); err != nil {
+		return nil
+	}
+
+	candidates := []string{cfg.URL}
+	for _, link := range cfg.Links {
+		candidates = append(candidates, link.URL)
+	}
+
+	var urls []string
+	for _, raw := range candidates {
+		raw = strings.TrimSpace(raw)
+		if raw != "" && validateBookmarkURL(raw) == nil {
+			urls = append(urls, raw)
+		}
+	}
+	return urls
+}
+
+func (s *ChannelService) linkPreviewsEnabled(ctx context.Context, channelID string) bool {
+	if s.unfurler == nil {
+		return false
+	}
+	settings, err := s.getChannelSettings(ctx, channelID)
+	if err != nil {
+		s.logger.WithError(err).WithField("channel_id", channelID).Warn("Failed to load settings for link previews")
+		return false
+	}
+	return settings.LinkPreviews
+}
+
+// GetLinkPreview unfurls rawURL for a channel member, serving from cache
+// when possible.
+func (s *ChannelService) GetLinkPreview(ctx context.Context, channelID, userID, rawURL string) (*models.LinkPreview, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if err := validateBookmarkURL(rawURL); err != nil {
+		return nil, err
+	}
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	if !s.linkPreviewsEnabled(ctx, channelID) {
+		return nil, ErrLinkPreviewsDisabled
+	}
+
+	preview, err := s.unfurler.Unfurl(ctx, rawURL)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		s.logger.WithError(err).WithField("url", rawURL).Debug("Link preview fetch failed")
+		return nil, ErrLinkPreviewUnavailable
+	}
+	return preview, nil
+}
+
+// warmLinkPreviews fetches previews in the background so later list calls
+// find them in the cache.
+func (s *ChannelService) warmLinkPreviews(ctx context.Context, channelID string, urls ...string) {
+	if len(urls) == 0 || !s.linkPreviewsEnabled(ctx, channelID) {
+		return
+	}
+	s.fetchPreviewsInBackground(urls)
+}
+
+// fetchPreviewsInBackground populates the cache for urls. Failures are
+// cached by the unfurler and otherwise ignored; without a cache there is
+// nothing to populate.
+func (s *ChannelService) fetchPreviewsInBackground(urls []string) {
+	if len(urls) == 0 || !s.unfurler.CacheEnabled() {
+		return
+	}
+	if len(urls) > maxWarmPreviewURLs {
+		urls = urls[:maxWarmPreviewURLs]
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), warmPreviewTimeout)
+		defer cancel()
+		for _, rawURL := range urls {
+			if ctx.Err() != nil {
+				return
+			}
+			s.unfurler.Unfurl(ctx, rawURL)
+		}
+	}()
+}
+
+// attachBookmarkPreviews fills in cached previews for URL bookmarks. It
+// never fetches, so listing stays fast; missing previews are warmed.
+func (s *ChannelService) attachBookmarkPreviews(ctx context.Context, channelID string, bookmarks []*models.BookmarkWithEntity) {
+	var urls []string
+	for _, b := range bookmarks {
+		if b.URL != nil {
+			urls = append(urls, *b.URL)
+		}
+	}
+	if len(urls) == 0 || !s.linkPreviewsEnabled(ctx, channelID) {
+		return
+	}
+
+	previews := s.unfurler.Cached(ctx, urls)
+	var missing []string
+	for _, b := range bookmarks {
+		if b.URL == nil {
+			continue
+		}
+		if preview, ok := previews[*b.URL]; ok {
+			b.Preview = preview
+		} else {
+			missing = append(missing, *b.URL)
+		}
+	}
+	s.fetchPreviewsInBackground(missing)
+}
+
+// attachTabPreviews fills in cached previews for the URLs of "links" tabs.
+func (s *ChannelService) attachTabPreviews(ctx context.Context, channelID string, tabs []*models.ChannelTab) {
+	tabURLs := make(map[*models.ChannelTab][]string)
+	var urls []string
+	for _, tab := range tabs {
+		if u := linksTabURLs(tab); len(u) > 0 {
+			tabURLs[tab] = u
+			urls = append(urls, u...)
+		}
+	}
+	if len(urls) == 0 || !s.linkPreviewsEnabled(ctx, channelID) {
+		return
+	}
+
+	previews := s.unfurler.Cached(ctx, urls)
+	var missing []string
+	for tab, u := range tabURLs {
+		for _, rawURL := range u {
+			if preview, ok := previews[rawURL]; ok {
+				tab.Previews = append(tab.Previews, preview)
+			} else {
+				missing = append(missing, rawURL)
+			}
+		}
+	}
+	s.fetchPreviewsInBackground(missing)
+}
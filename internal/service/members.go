@@ -0,0 +1,447 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/quckapp/channel-service/internal/repository"
+)
+
+// ── Membership & Capacity ──
+
+const (
+	ActionMemberAdded   = "member.added"
+	ActionMemberRemoved = "member.removed"
+	ActionInviteCreated = "invite.created"
+
+	inviteCodeLength   = 10
+	inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz23456789"
+)
+
+func newChannelMember(channelID, userID, role string, joinedAt time.Time) *models.ChannelMember {
+	if role == "" {
+		role = "member"
+	}
+	return &models.ChannelMember{
+		ID:            uuid.New().String(),
+		ChannelID:     channelID,
+		UserID:        userID,
+		Role:          role,
+		Notifications: "all",
+		JoinedAt:      joinedAt,
+	}
+}
+
+// getJoinableChannel loads the channel and its member limit for an add.
+func (s *ChannelService) getJoinableChannel(ctx context.Context, channelID string) (*models.Channel, int, error) {
+	channel, err := s.getChannel(ctx, channelID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if channel.IsArchived {
+		return nil, 0, ErrChannelArchived
+	}
+	settings, err := s.getChannelSettings(ctx, channelID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return channel, settings.MemberLimit, nil
+}
+
+// AddMember adds a user to a channel. Users may join public channels
+// themselves; adding anyone else requires a channel admin, and only owners
+// may add owners. When the channel is full the user is waitlisted if
+// requested, otherwise ErrChannelFull is returned.
+func (s *ChannelService) AddMember(ctx context.Context, channelID, actorID string, req *models.AddMemberRequest) (*models.JoinResult, error) {
+	channel, limit, err := s.getJoinableChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	selfJoin := req.UserID == actorID && channel.Type == "public" && (req.Role == "" || req.Role == "member")
+	if !selfJoin {
+		if err := s.requireChannelAdmin(ctx, channelID, actorID); err != nil {
+			return nil, err
+		}
+		if req.Role == "owner" {
+			role, err := s.memberRepo.GetRole(ctx, channelID, actorID)
+			if err != nil {
+				return nil, err
+			}
+			if role != "owner" {
+				return nil, ErrForbidden
+			}
+		}
+	}
+
+	member := newChannelMember(channelID, req.UserID, req.Role, time.Now())
+	added, existing, full, err := s.memberRepo.AddWithinLimit(ctx, channelID, []*models.ChannelMember{member}, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, ErrAlreadyMember
+	}
+	if len(full) > 0 {
+		if !req.Waitlist {
+			return nil, ErrChannelFull
+		}
+		return s.joinWaitlist(ctx, channelID, actorID, member)
+	}
+
+	s.afterMembersAdded(ctx, channelID, actorID, added, "direct")
+	return &models.JoinResult{Member: member}, nil
+}
+
+// BulkAddMembers imports many users at once, in order, filling the channel
+// up to its member limit. Users that do not fit are waitlisted if requested
+// and otherwise reported as rejected.
+func (s *ChannelService) BulkAddMembers(ctx context.Context, channelID, actorID string, req *models.BulkAddMembersRequest) (*models.MemberAddResult, error) {
+	_, limit, err := s.getJoinableChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireChannelAdmin(ctx, channelID, actorID); err != nil {
+		return nil, err
+	}
+
+	role := req.Role
+	if role == "" {
+		role = "member"
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool, len(req.UserIDs))
+	members := make([]*models.ChannelMember, 0, len(req.UserIDs))
+	for _, userID := range req.UserIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		members = append(members, newChannelMember(channelID, userID, role, now))
+	}
+
+	added, existing, full, err := s.memberRepo.AddWithinLimit(ctx, channelID, members, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.MemberAddResult{
+		Added:          added,
+		AlreadyMembers: existing,
+		Waitlisted:     []string{},
+		Rejected:       []string{},
+	}
+	if result.Added == nil {
+		result.Added = []*models.ChannelMember{}
+	}
+	if result.AlreadyMembers == nil {
+		result.AlreadyMembers = []string{}
+	}
+
+	if req.Waitlist {
+		for _, userID := range full {
+			entry := &models.WaitlistEntry{
+				ChannelID: channelID,
+				UserID:    userID,
+				Role:      role,
+				AddedBy:   actorID,
+				CreatedAt: time.Now(),
+			}
+			if err := s.memberRepo.AddToWaitlist(ctx, entry); err != nil {
+				return nil, err
+			}
+			result.Waitlisted = append(result.Waitlisted, userID)
+		}
+	} else if len(full) > 0 {
+		result.Rejected = full
+	}
+
+	s.afterMembersAdded(ctx, channelID, actorID, added, "bulk_import")
+	if len(result.Waitlisted) > 0 {
+		s.admitFromWaitlist(ctx, channelID)
+	}
+
+	return result, nil
+}
+
+// RemoveMember removes userID from the channel. Members may always leave;
+// removing someone else requires an admin, and only owners may remove owners.
+func (s *ChannelService) RemoveMember(ctx context.Context, channelID, actorID, userID string) error {
+	if _, err := s.getChannel(ctx, channelID); err != nil {
+		return err
+	}
+
+	if userID != actorID {
+		if err := s.requireChannelAdmin(ctx, channelID, actorID); err != nil {
+			return err
+		}
+		targetRole, err := s.memberRepo.GetRole(ctx, channelID, userID)
+		if err != nil {
+			return ErrMemberNotFound
+		}
+		if targetRole == "owner" {
+			actorRole, err := s.memberRepo.GetRole(ctx, channelID, actorID)
+			if err != nil {
+				return err
+			}
+			if actorRole != "owner" {
+				return ErrForbidden
+			}
+		}
+	}
+
+	removed, err := s.memberRepo.RemoveMember(ctx, channelID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrMemberNotFound
+	}
+
+	s.afterMemberRemoved(ctx, channelID, actorID, userID)
+	return nil
+}
+
+func (s *ChannelService) ListWaitlist(ctx context.Context, channelID, actorID string) ([]*models.WaitlistEntry, error) {
+	if err := s.requireChannelAdmin(ctx, channelID, actorID); err != nil {
+		return nil, err
+	}
+	return s.memberRepo.ListWaitlist(ctx, channelID)
+}
+
+// RemoveFromWaitlist lets users leave the waitlist and admins clear entries.
+func (s *ChannelService) RemoveFromWaitlist(ctx context.Context, channelID, actorID, userID string) error {
+	if userID != actorID {
+		if err := s.requireChannelAdmin(ctx, channelID, actorID); err != nil {
+			return err
+		}
+	}
+	removed, err := s.memberRepo.RemoveFromWaitlist(ctx, channelID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotWaitlisted
+	}
+	return nil
+}
+
+// ── Invites ──
+
+// CreateInvite creates a shareable invite code. Members may create invites
+// while allow_invites is on; otherwise only admins may.
+func (s *ChannelService) CreateInvite(ctx context.Context, channelID, userID string, req *models.CreateInviteRequest) (*models.ChannelInvite, error) {
+	if _, _, err := s.getJoinableChannel(ctx, channelID); err != nil {
+		return nil, err
+	}
+	settings, err := s.getChannelSettings(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.AllowInvites {
+		err = s.requireMember(ctx, channelID, userID)
+	} else {
+		err = s.requireChannelAdmin(ctx, channelID, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	code, err := generateInviteCode()
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &models.ChannelInvite{
+		ID:        uuid.New().String(),
+		ChannelID: channelID,
+		CreatedBy: userID,
+		Code:      code,
+		MaxUses:   req.MaxUses,
+		ExpiresAt: req.ExpiresAt,
+		IsActive:  true,
+		CreatedAt: time.Now(),
+	}
+	if err := s.inviteRepo.Create(ctx, invite); err != nil {
+		return nil, err
+	}
+
+	s.logActivity(ctx, channelID, userID, ActionInviteCreated, &invite.ID, map[string]interface{}{
+		"max_uses":   invite.MaxUses,
+		"expires_at": invite.ExpiresAt,
+	})
+	return invite, nil
+}
+
+// RedeemInvite joins the invite's channel. The invite use and the membership
+// are committed together, so a full channel does not consume a use.
+func (s *ChannelService) RedeemInvite(ctx context.Context, code, userID string, req *models.RedeemInviteRequest) (*models.JoinResult, error) {
+	invite, err := s.inviteRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if invite == nil {
+		return nil, ErrInviteNotFound
+	}
+	if invite.ExpiresAt != nil && invite.ExpiresAt.Before(time.Now()) {
+		return nil, ErrInviteExhausted
+	}
+
+	_, limit, err := s.getJoinableChannel(ctx, invite.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+
+	member := newChannelMember(invite.ChannelID, userID, "member", time.Now())
+	outcome, err := s.inviteRepo.Redeem(ctx, invite, member, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	switch outcome {
+	case repository.RedeemAlreadyMember:
+		return nil, ErrAlreadyMember
+	case repository.RedeemInviteExhausted:
+		return nil, ErrInviteExhausted
+	case repository.RedeemChannelFull:
+		if !req.Waitlist {
+			return nil, ErrChannelFull
+		}
+		return s.joinWaitlist(ctx, invite.ChannelID, invite.CreatedBy, member)
+	}
+
+	s.afterMembersAdded(ctx, invite.ChannelID, userID, []*models.ChannelMember{member}, "invite")
+	return &models.JoinResult{Member: member}, nil
+}
+
+func generateInviteCode() (string, error) {
+	code := make([]byte, inviteCodeLength)
+	max := big.NewInt(int64(len(inviteCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = inviteCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// ── Membership Hooks ──
+
+func (s *ChannelService) joinWaitlist(ctx context.Context, channelID, addedBy string, member *models.ChannelMember) (*models.JoinResult, error) {
+	entry := &models.WaitlistEntry{
+		ChannelID: channelID,
+		UserID:    member.UserID,
+		Role:      member.Role,
+		AddedBy:   addedBy,
+		CreatedAt: time.Now(),
+	}
+	if err := s.memberRepo.AddToWaitlist(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	// A slot may have freed between the capacity check and the enqueue.
+	s.admitFromWaitlist(ctx, channelID)
+
+	position, err := s.memberRepo.GetWaitlistPosition(ctx, channelID, member.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if position == 0 {
+		// Admitted straight away.
+		joined, err := s.memberRepo.GetByChannelAndUser(ctx, channelID, member.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return &models.JoinResult{Member: joined}, nil
+	}
+	return &models.JoinResult{Waitlisted: true, WaitlistPosition: position}, nil
+}
+
+// admitFromWaitlist fills free slots from the waitlist, oldest first.
+// Failures are logged; the waitlist is retried on the next slot change.
+func (s *ChannelService) admitFromWaitlist(ctx context.Context, channelID string) {
+	entries, err := s.memberRepo.ListWaitlist(ctx, channelID)
+	if err != nil || len(entries) == 0 {
+		if err != nil {
+			s.logger.WithError(err).WithField("channel_id", channelID).Warn("Failed to load waitlist")
+		}
+		return
+	}
+
+	_, limit, err := s.getJoinableChannel(ctx, channelID)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	members := make([]*models.ChannelMember, 0, len(entries))
+	for _, e := range entries {
+		members = append(members, newChannelMember(channelID, e.UserID, e.Role, now))
+	}
+
+	admitted, err := s.memberRepo.AdmitFromWaitlist(ctx, channelID, members, limit)
+	if err != nil {
+		s.logger.WithError(err).WithField("channel_id", channelID).Warn("Failed to admit from waitlist")
+		return
+	}
+	s.afterMembersAdded(ctx, channelID, systemActorID, admitted, "waitlist")
+}
+
+// afterMembersAdded publishes join events, audits the adds and drops the
+// new members' cached unread summaries.
+func (s *ChannelService) afterMembersAdded(ctx context.Context, channelID, actorID string, members []*models.ChannelMember, source string) {
+	if len(members) == 0 {
+		return
+	}
+
+	userIDs := make([]string, 0, len(members))
+	for _, m := range members {
+		userIDs = append(userIDs, m.UserID)
+		s.publishEvent(ctx, TopicMemberJoined, channelID, &models.MemberEvent{
+			EventID:    uuid.New().String(),
+			Type:       TopicMemberJoined,
+			ChannelID:  channelID,
+			UserID:     m.UserID,
+			OccurredAt: m.JoinedAt,
+		})
+		target := m.UserID
+		s.logActivity(ctx, channelID, actorID, ActionMemberAdded, &target, map[string]interface{}{
+			"role":   m.Role,
+			"source": source,
+		})
+	}
+	s.invalidateUnread(ctx, userIDs...)
+}
+
+// afterMemberRemoved runs once a membership has ended.
+func (s *ChannelService) afterMemberRemoved(ctx context.Context, channelID, actorID, userID string) {
+	s.publishEvent(ctx, TopicMemberLeft, channelID, &models.MemberEvent{
+		EventID:    uuid.New().String(),
+		Type:       TopicMemberLeft,
+		ChannelID:  channelID,
+		UserID:     userID,
+		OccurredAt: time.Now(),
+	})
+	target := userID
+	s.logActivity(ctx, channelID, actorID, ActionMemberRemoved, &target, nil)
+	s.invalidateUnread(ctx, userID)
+
+	if err := s.starredRepo.Unstar(ctx, userID, channelID); err != nil {
+		s.logger.WithError(err).WithField("channel_id", channelID).Warn("Failed to unstar channel after leave")
+	}
+	if err := s.removeChannelFromSections(ctx, userID, channelID); err != nil {
+		s.logger.WithError(err).WithField("channel_id", channelID).Warn("Failed to remove channel from sections after leave")
+	}
+
+	s.admitFromWaitlist(ctx, channelID)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/quckapp/channel-service/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// ── Message Events ──
+
+const (
+	TopicMessageCreated = "message.created"
+	TopicMessageDeleted = "message.deleted"
+)
+
+// HandleMessageEvent consumes events published by the message service.
+// Processing is keyed by message ID, so redelivered events are no-ops.
+// Malformed events, and events the database rejects outright (such as one
+// for a channel that no longer exists), are discarded; other processing
+// errors are returned so the event is retried.
+func (s *ChannelService) HandleMessageEvent(ctx context.Context, topic string, key, value []byte) error {
+	var event models.MessageEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		s.logger.WithError(err).WithField("topic", topic).Warn("Discarding malformed message event")
+		return nil
+	}
+	if event.Type == "" {
+		event.Type = topic
+	}
+	if event.MessageID == "" || event.ChannelID == "" {
+		s.logger.WithField("topic", topic).Warn("Discarding message event without message or channel ID")
+		return nil
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	var err error
+	switch event.Type {
+	case TopicMessageCreated:
+		err = s.handleMessageCreated(ctx, &event)
+	case TopicMessageDeleted:
+		err = s.handleMessageDeleted(ctx, &event)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		log := s.logger.WithError(err).WithFields(logrus.Fields{
+			"type":       event.Type,
+			"message_id": event.MessageID,
+			"channel_id": event.ChannelID,
+		})
+		if repository.IsPermanent(err) {
+			log.Warn("Discarding message event that cannot be applied")
+			return nil
+		}
+		log.Error("Failed to handle message event")
+	}
+	return err
+}
+
+func (s *ChannelService) handleMessageCreated(ctx context.Context, event *models.MessageEvent) error {
+	msg := &models.ChannelMessage{
+		ID:        event.MessageID,
+		ChannelID: event.ChannelID,
+		UserID:    event.UserID,
+		ThreadID:  event.ThreadID,
+		CreatedAt: event.CreatedAt,
+	}
+
+	mentions := make([]string, 0, len(event.Mentions))
+	for _, userID := range event.Mentions {
+		if userID != "" && userID != event.UserID {
+			mentions = append(mentions, userID)
+		}
+	}
+
+	metric := repository.MetricMessages
+	if event.ThreadID != nil && *event.ThreadID != "" {
+		metric = repository.MetricThreadReplies
+	}
+	if _, err := s.messageRepo.RecordCreated(ctx, msg, mentions, metric); err != nil {
+		return err
+	}
+
+	// The steps below are idempotent, so they also run for replays: a
+	// retry after one of them failed still completes them.
+
+	// Posting a message implies the author has read the channel up to it.
+	if err := s.memberRepo.UpdateLastReadAt(ctx, event.ChannelID, event.UserID, event.CreatedAt); err != nil {
+		return err
+	}
+
+	return s.invalidateChannelUnread(ctx, event.ChannelID)
+}
+
+func (s *ChannelService) handleMessageDeleted(ctx context.Context, event *models.MessageEvent) error {
+	deletedAt := time.Now()
+	if event.DeletedAt != nil {
+		deletedAt = *event.DeletedAt
+	}
+
+	if _, err := s.messageRepo.RecordDeleted(ctx, &models.ChannelMessage{
+		ID:        event.MessageID,
+		ChannelID: event.ChannelID,
+		UserID:    event.UserID,
+		ThreadID:  event.ThreadID,
+		CreatedAt: event.CreatedAt,
+		DeletedAt: &deletedAt,
+	}); err != nil {
+		return err
+	}
+
+	// Idempotent, so replays retry it too.
+	return s.invalidateChannelUnread(ctx, event.ChannelID)
+}
+
+func (s *ChannelService) invalidateChannelUnread(ctx context.Context, channelID string) error {
+	if s.redis == nil {
+		return nil
+	}
+	userIDs, err := s.memberRepo.ListUserIDs(ctx, channelID)
+	if err != nil {
+		return err
+	}
+	s.invalidateUnread(ctx, userIDs...)
+	return nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/quckapp/channel-service/internal/repository"
+)
+
+// ── Reactions ──
+
+const maxReactionSummaryMessages = 100
+
+var customEmojiPattern = regexp.MustCompile(`^:[a-z0-9_+\-]+:$`)
+
+func isCustomEmoji(emoji string) bool {
+	return customEmojiPattern.MatchString(emoji)
+}
+
+func (s *ChannelService) checkReactionAllowed(ctx context.Context, channelID, emoji string) error {
+	settings, err := s.getChannelSettings(ctx, channelID)
+	if err != nil {
+		return err
+	}
+	if !settings.AllowReactions {
+		return ErrReactionsDisabled
+	}
+	if isCustomEmoji(emoji) && !settings.CustomEmoji {
+		return ErrCustomEmojiDisabled
+	}
+	return nil
+}
+
+// ToggleReaction adds the reaction if the user has not reacted with that emoji
+// yet and removes it otherwise. Removing is always allowed so reactions left
+// behind by a setting change or a deleted custom emoji can still be cleaned up.
+func (s *ChannelService) ToggleReaction(ctx context.Context, channelID, messageID, userID string, req *models.ToggleReactionRequest) (*models.ToggleReactionResult, error) {
+	emoji := req.Emoji
+	exists, err := s.reactionRepo.Exists(ctx, channelID, messageID, userID, emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	var channel *models.Channel
+	var custom *models.CustomEmoji
+	if !exists {
+		channel, err = s.getChannel(ctx, channelID)
+		if err != nil {
+			return nil, err
+		}
+		// Resolve aliases first so the settings check sees the emoji that
+		// will actually be stored.
+		emoji, custom, err = s.resolveReactionEmoji(ctx, channel.WorkspaceID, emoji)
+		if err != nil {
+			return nil, err
+		}
+		if emoji != req.Emoji {
+			exists, err = s.reactionRepo.Exists(ctx, channelID, messageID, userID, emoji)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !exists {
+			if err := s.checkReactionAllowed(ctx, channelID, emoji); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if exists {
+		if err := s.reactionRepo.Delete(ctx, channelID, messageID, userID, emoji); err != nil {
+			return nil, err
+		}
+	} else {
+		reaction := &models.ChannelReaction{
+			ID:        uuid.New().String(),
+			ChannelID: channelID,
+			MessageID: messageID,
+			UserID:    userID,
+			Emoji:     emoji,
+			CreatedAt: time.Now(),
+		}
+		// A concurrent toggle that already added it counts as added.
+		created, err := s.reactionRepo.Create(ctx, reaction)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			s.recordEmojiUsage(ctx, channel.WorkspaceID, userID, emoji, custom)
+			s.recordActivity(ctx, channelID, userID, repository.MetricReactions, reaction.CreatedAt)
+		}
+	}
+
+	summary, err := s.reactionRepo.GetSummary(ctx, channelID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ToggleReactionResult{
+		Added:     !exists,
+		Reactions: summary,
+	}, nil
+}
+
+// ListReactors groups the users who reacted to a message by emoji, in the
+// order the first reaction with each emoji was added. An empty emoji lists
+// every emoji on the message.
+func (s *ChannelService) ListReactors(ctx context.Context, channelID, messageID, userID, emoji string) ([]*models.ReactionUsers, error) {
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	reactions, err := s.reactionRepo.ListByMessage(ctx, channelID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []*models.ReactionUsers{}
+	byEmoji := make(map[string]*models.ReactionUsers)
+	for _, reaction := range reactions {
+		if emoji != "" && reaction.Emoji != emoji {
+			continue
+		}
+		group, ok := byEmoji[reaction.Emoji]
+		if !ok {
+			group = &models.ReactionUsers{Emoji: reaction.Emoji, UserIDs: []string{}}
+			byEmoji[reaction.Emoji] = group
+			groups = append(groups, group)
+		}
+		group.UserIDs = append(group.UserIDs, reaction.UserID)
+		group.Count++
+	}
+
+	return groups, nil
+}
+
+// GetReactionSummaries returns the reaction counts for a page of messages in a
+// single query, keyed by message ID. Messages without reactions map to an
+// empty list so clients can tell "no reactions" from "not requested".
+func (s *ChannelService) GetReactionSummaries(ctx context.Context, channelID, userID string, messageIDs []string) (map[string][]*models.MessageReactionSummary, error) {
+	if len(messageIDs) > maxReactionSummaryMessages {
+		return nil, ErrTooManyMessageIDs
+	}
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]*models.MessageReactionSummary, len(messageIDs))
+	for _, id := range messageIDs {
+		result[id] = []*models.MessageReactionSummary{}
+	}
+	if len(messageIDs) == 0 {
+		return result, nil
+	}
+
+	summaries, err := s.reactionRepo.GetSummaries(ctx, channelID, userID, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range summaries {
+		result[summary.MessageID] = append(result[summary.MessageID], summary)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Read Receipts & Unread ──
+
+const unreadCacheTTL = 10 * time.Minute
+
+// Unread summaries are cached in one hash per user, keyed by the workspace
+// filter, so invalidation is a single DEL regardless of how they were queried.
+func unreadCacheKey(userID string) string {
+	return "channel:unread:" + userID
+}
+
+// MarkRead moves the user's read position in the channel up to messageID.
+// The position never moves backwards, so a late request for an older message
+// leaves it where it is.
+func (s *ChannelService) MarkRead(ctx context.Context, channelID, userID string, req *models.MarkReadRequest) (*models.ReadReceipt, error) {
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	msg, err := s.messageRepo.GetByID(ctx, req.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil || msg.ChannelID != channelID {
+		return nil, ErrMessageNotFound
+	}
+
+	receipt := &models.ReadReceipt{
+		ID:        uuid.New().String(),
+		ChannelID: channelID,
+		UserID:    userID,
+		MessageID: req.MessageID,
+		ReadAt:    time.Now(),
+	}
+	if err := s.readReceiptRepo.Upsert(ctx, receipt, msg.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := s.memberRepo.UpdateLastReadAt(ctx, channelID, userID, msg.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	s.invalidateUnread(ctx, userID)
+
+	// Return the stored receipt, which stays put for an older message.
+	if stored, err := s.readReceiptRepo.GetByChannelAndUser(ctx, channelID, userID); err == nil && stored != nil {
+		return stored, nil
+	}
+	return receipt, nil
+}
+
+func (s *ChannelService) GetMessageReaders(ctx context.Context, channelID, messageID, userID string) (*models.MessageReaders, error) {
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	msg, err := s.messageRepo.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil || msg.ChannelID != channelID {
+		return nil, ErrMessageNotFound
+	}
+
+	readers, err := s.memberRepo.ListReadSince(ctx, channelID, msg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if readers == nil {
+		readers = []*models.MessageReader{}
+	}
+
+	return &models.MessageReaders{
+		MessageID: messageID,
+		ReadCount: len(readers),
+		Readers:   readers,
+	}, nil
+}
+
+func (s *ChannelService) GetUnreadSummary(ctx context.Context, userID, workspaceID string) (*models.UnreadSummary, error) {
+	key := unreadCacheKey(userID)
+	if s.redis != nil {
+		if data, err := s.redis.HGet(ctx, key, workspaceID).Bytes(); err == nil {
+			var summary models.UnreadSummary
+			if err := json.Unmarshal(data, &summary); err == nil {
+				return &summary, nil
+			}
+		}
+	}
+
+	channels, err := s.messageRepo.ListUnreadByUser(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.UnreadSummary{Channels: []*models.ChannelUnread{}}
+	for _, ch := range channels {
+		if ch.UnreadCount == 0 && ch.MentionCount == 0 {
+			continue
+		}
+		summary.TotalUnread += ch.UnreadCount
+		summary.TotalMentions += ch.MentionCount
+		summary.Channels = append(summary.Channels, ch)
+	}
+
+	if s.redis != nil {
+		if data, err := json.Marshal(summary); err == nil {
+			pipe := s.redis.TxPipeline()
+			pipe.HSet(ctx, key, workspaceID, data)
+			pipe.Expire(ctx, key, unreadCacheTTL)
+			if _, err := pipe.Exec(ctx); err != nil {
+				s.logger.WithError(err).Warn("Failed to cache unread summary")
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+func (s *ChannelService) invalidateUnread(ctx context.Context, userIDs ...string) {
+	if s.redis == nil || len(userIDs) == 0 {
+		return
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		keys[i] = unreadCacheKey(userID)
+	}
+	if err := s.redis.Del(ctx, keys...).Err(); err != nil {
+		s.logger.WithError(err).Warn("Failed to invalidate unread cache")
+	}
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Sidebar Sections ──
+
+// decodeSectionChannels fills Channels from the channel_ids JSON column.
+func decodeSectionChannels(section *models.ChannelSection) {
+	section.Channels = []string{}
+	if section.ChannelIDs != nil && *section.ChannelIDs != "" {
+		json.Unmarshal([]byte(*section.ChannelIDs), &section.Channels)
+	}
+}
+
+// encodeSectionChannels writes Channels back to the channel_ids JSON column.
+func encodeSectionChannels(section *models.ChannelSection) {
+	if section.Channels == nil {
+		section.Channels = []string{}
+	}
+	data, _ := json.Marshal(section.Channels)
+	encoded := string(data)
+	section.ChannelIDs = &encoded
+}
+
+func (s *ChannelService) getOwnSection(ctx context.Context, sectionID, userID string) (*models.ChannelSection, error) {
+	section, err := s.sectionRepo.GetByID(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	if section == nil || section.UserID != userID {
+		return nil, ErrSectionNotFound
+	}
+	decodeSectionChannels(section)
+	return section, nil
+}
+
+func (s *ChannelService) CreateSection(ctx context.Context, userID string, req *models.CreateSectionRequest) (*models.ChannelSection, error) {
+	maxPos, err := s.sectionRepo.GetMaxPosition(ctx, req.WorkspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	section := &models.ChannelSection{
+		ID:          uuid.New().String(),
+		WorkspaceID: req.WorkspaceID,
+		UserID:      userID,
+		Name:        req.Name,
+		Position:    maxPos + 1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	encodeSectionChannels(section)
+
+	if err := s.sectionRepo.Create(ctx, section); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+// UpdateSection renames and/or collapses a section.
+func (s *ChannelService) UpdateSection(ctx context.Context, sectionID, userID string, req *models.UpdateSectionRequest) (*models.ChannelSection, error) {
+	section, err := s.getOwnSection(ctx, sectionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		section.Name = *req.Name
+	}
+	if req.IsCollapsed != nil {
+		section.IsCollapsed = *req.IsCollapsed
+	}
+	section.UpdatedAt = time.Now()
+
+	if err := s.sectionRepo.Update(ctx, section); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+// DeleteSection removes a section; its channels become uncategorized.
+func (s *ChannelService) DeleteSection(ctx context.Context, sectionID, userID string) error {
+	if _, err := s.getOwnSection(ctx, sectionID, userID); err != nil {
+		return err
+	}
+	return s.sectionRepo.Delete(ctx, sectionID)
+}
+
+func (s *ChannelService) ReorderSections(ctx context.Context, userID string, req *models.ReorderSectionsRequest) ([]*models.ChannelSection, error) {
+	ok, err := s.sectionRepo.Reorder(ctx, req.WorkspaceID, userID, req.SectionIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidSectionOrder
+	}
+
+	sections, err := s.sectionRepo.ListByUser(ctx, req.WorkspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, section := range sections {
+		decodeSectionChannels(section)
+	}
+	return sections, nil
+}
+
+// MoveChannel places a channel the user belongs to into one of their
+// sections, or back to uncategorized, removing it from any other section.
+func (s *ChannelService) MoveChannel(ctx context.Context, channelID, userID string, req *models.MoveChannelRequest) error {
+	channel, err := s.getChannel(ctx, channelID)
+	if err != nil {
+		return err
+	}
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return err
+	}
+
+	var target *models.ChannelSection
+	if req.SectionID != "" {
+		target, err = s.getOwnSection(ctx, req.SectionID, userID)
+		if err != nil {
+			return err
+		}
+		if target.WorkspaceID != channel.WorkspaceID {
+			return ErrWorkspaceMismatch
+		}
+	}
+
+	return s.placeChannel(ctx, userID, channelID, target, req.Position)
+}
+
+// removeChannelFromSections drops channelID from every section of the user.
+func (s *ChannelService) removeChannelFromSections(ctx context.Context, userID, channelID string) error {
+	return s.placeChannel(ctx, userID, channelID, nil, nil)
+}
+
+// placeChannel removes channelID from the user's sections and, if target is
+// set, inserts it into target at position (or last). The whole change runs
+// under a lock on the user's sections.
+func (s *ChannelService) placeChannel(ctx context.Context, userID, channelID string, target *models.ChannelSection, position *int) error {
+	return s.sectionRepo.UpdateLocked(ctx, userID, func(sections []*models.ChannelSection) ([]*models.ChannelSection, error) {
+		var changed []*models.ChannelSection
+		var dest *models.ChannelSection
+		for _, section := range sections {
+			decodeSectionChannels(section)
+			kept := section.Channels[:0]
+			for _, id := range section.Channels {
+				if id != channelID {
+					kept = append(kept, id)
+				}
+			}
+			removed := len(kept) != len(section.Channels)
+			section.Channels = kept
+			if target != nil && section.ID == target.ID {
+				dest = section
+			} else if removed {
+				changed = append(changed, section)
+			}
+		}
+		if target != nil {
+			if dest == nil {
+				// Deleted since MoveChannel looked it up.
+				return nil, ErrSectionNotFound
+			}
+			pos := len(dest.Channels)
+			if position != nil && *position < pos {
+				pos = *position
+			}
+			dest.Channels = append(dest.Channels, "")
+			copy(dest.Channels[pos+1:], dest.Channels[pos:])
+			dest.Channels[pos] = channelID
+			changed = append(changed, dest)
+		}
+
+		for _, section := range changed {
+			encodeSectionChannels(section)
+		}
+		return changed, nil
+	})
+}
+
+// GetSidebar assembles the user's sidebar for a workspace: starred channels,
+// their sections in order, and every other channel as uncategorized, each
+// with unread and mention counts. Channels appear in one group only, and
+// section entries for channels the user has left are skipped.
+func (s *ChannelService) GetSidebar(ctx context.Context, userID, workspaceID string) (*models.Sidebar, error) {
+	channels, err := s.channelRepo.ListByUser(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	starred, err := s.starredRepo.ListChannelsByUser(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := s.sectionRepo.ListByUser(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.GetUnreadSummary(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	unreadByChannel := make(map[string]*models.ChannelUnread, len(unread.Channels))
+	for _, u := range unread.Channels {
+		unreadByChannel[u.ChannelID] = u
+	}
+	entry := func(ch *models.Channel) *models.SidebarChannel {
+		item := &models.SidebarChannel{Channel: ch}
+		if u, ok := unreadByChannel[ch.ID]; ok {
+			item.UnreadCount = u.UnreadCount
+			item.MentionCount = u.MentionCount
+		}
+		return item
+	}
+
+	byID := make(map[string]*models.Channel, len(channels))
+	for _, ch := range channels {
+		byID[ch.ID] = ch
+	}
+	placed := make(map[string]bool, len(channels))
+
+	sidebar := &models.Sidebar{
+		WorkspaceID:   workspaceID,
+		Starred:       []*models.SidebarChannel{},
+		Sections:      []*models.SidebarSection{},
+		Uncategorized: []*models.SidebarChannel{},
+		TotalUnread:   unread.TotalUnread,
+		TotalMentions: unread.TotalMentions,
+	}
+
+	for _, star := range starred {
+		ch, ok := byID[star.ID]
+		if !ok || placed[ch.ID] {
+			continue
+		}
+		placed[ch.ID] = true
+		sidebar.Starred = append(sidebar.Starred, entry(ch))
+	}
+
+	for _, section := range sections {
+		decodeSectionChannels(section)
+		group := &models.SidebarSection{
+			ID:          section.ID,
+			Name:        section.Name,
+			Position:    section.Position,
+			IsCollapsed: section.IsCollapsed,
+			Channels:    []*models.SidebarChannel{},
+		}
+		for _, id := range section.Channels {
+			ch, ok := byID[id]
+			if !ok || placed[id] {
+				continue
+			}
+			placed[id] = true
+			group.Channels = append(group.Channels, entry(ch))
+		}
+		sidebar.Sections = append(sidebar.Sections, group)
+	}
+
+	for _, ch := range channels {
+		if !placed[ch.ID] {
+			sidebar.Uncategorized = append(sidebar.Uncategorized, entry(ch))
+		}
+	}
+
+	return sidebar, nil
+}
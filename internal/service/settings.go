@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+const ActionSettingsUpdated = "settings.updated"
+
+// ── Channel Settings ──
+
+// defaultChannelSettings mirrors the column defaults of channel_settings so
+// channels without a settings row behave exactly as if one had been inserted.
+func defaultChannelSettings(channelID string) *models.ChannelSetting {
+	now := time.Now()
+	return &models.ChannelSetting{
+		ChannelID:           channelID,
+		SlowModeInterval:    0,
+		MaxPins:             50,
+		MaxBookmarks:        100,
+		AllowThreads:        true,
+		AllowReactions:      true,
+		AllowInvites:        true,
+		AutoArchiveDays:     0,
+		DefaultNotification: "all",
+		CustomEmoji:         false,
+		LinkPreviews:        true,
+		MemberLimit:         0,
+		VoiceStageMode:      false,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+}
+
+func (s *ChannelService) getChannelSettings(ctx context.Context, channelID string) (*models.ChannelSetting, error) {
+	settings, err := s.settingsRepo.Get(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return defaultChannelSettings(channelID), nil
+	}
+	return settings, nil
+}
+
+func (s *ChannelService) GetChannelSettings(ctx context.Context, channelID, userID string) (*models.ChannelSetting, error) {
+	if _, err := s.getChannel(ctx, channelID); err != nil {
+		return nil, err
+	}
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	return s.getChannelSettings(ctx, channelID)
+}
+
+// UpdateChannelSettings applies a partial update and records the fields
+// that actually changed, with old and new values, in the activity log.
+func (s *ChannelService) UpdateChannelSettings(ctx context.Context, channelID, userID string, req *models.UpdateChannelSettingsRequest) (*models.ChannelSetting, error) {
+	if _, err := s.getChannel(ctx, channelID); err != nil {
+		return nil, err
+	}
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]models.SettingChange)
+	settings, err := s.settingsRepo.UpdateLocked(ctx, channelID, defaultChannelSettings(channelID), func(settings *models.ChannelSetting) bool {
+		applyInt := func(name string, dst *int, v *int) {
+			if v != nil && *v != *dst {
+				changes[name] = models.SettingChange{Old: *dst, New: *v}
+				*dst = *v
+			}
+		}
+		applyBool := func(name string, dst *bool, v *bool) {
+			if v != nil && *v != *dst {
+				changes[name] = models.SettingChange{Old: *dst, New: *v}
+				*dst = *v
+			}
+		}
+
+		applyInt("slow_mode_interval", &settings.SlowModeInterval, req.SlowModeInterval)
+		applyInt("max_pins", &settings.MaxPins, req.MaxPins)
+		applyInt("max_bookmarks", &settings.MaxBookmarks, req.MaxBookmarks)
+		applyBool("allow_threads", &settings.AllowThreads, req.AllowThreads)
+		applyBool("allow_reactions", &settings.AllowReactions, req.AllowReactions)
+		applyBool("allow_invites", &settings.AllowInvites, req.AllowInvites)
+		applyInt("auto_archive_days", &settings.AutoArchiveDays, req.AutoArchiveDays)
+		if req.DefaultNotification != nil && *req.DefaultNotification != settings.DefaultNotification {
+			changes["default_notification"] = models.SettingChange{Old: settings.DefaultNotification, New: *req.DefaultNotification}
+			settings.DefaultNotification = *req.DefaultNotification
+		}
+		applyBool("custom_emoji", &settings.CustomEmoji, req.CustomEmoji)
+		applyBool("link_previews", &settings.LinkPreviews, req.LinkPreviews)
+		applyInt("member_limit", &settings.MemberLimit, req.MemberLimit)
+		applyBool("voice_stage_mode", &settings.VoiceStageMode, req.VoiceStageMode)
+
+		if len(changes) == 0 {
+			return false
+		}
+		if settings.ID == "" {
+			settings.ID = uuid.New().String()
+		}
+		settings.UpdatedAt = time.Now()
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return settings, nil
+	}
+
+	s.logActivity(ctx, channelID, userID, ActionSettingsUpdated, nil, map[string]interface{}{"changes": changes})
+
+	// A raised or removed member limit may free slots for waitlisted users.
+	if _, ok := changes["member_limit"]; ok {
+		s.admitFromWaitlist(ctx, channelID)
+	}
+	if _, ok := changes["voice_stage_mode"]; ok {
+		s.applyStageMode(ctx, channelID, userID, settings.VoiceStageMode)
+	}
+
+	return settings, nil
+}
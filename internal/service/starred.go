@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Starred Channels ──
+
+const maxStarredChannels = 100
+
+func (s *ChannelService) StarChannel(ctx context.Context, channelID, userID string) (*models.StarredChannel, error) {
+	if _, err := s.getChannel(ctx, channelID); err != nil {
+		return nil, err
+	}
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	starred, err := s.starredRepo.IsStarred(ctx, userID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if starred {
+		return nil, ErrAlreadyStarred
+	}
+
+	// Stars on deleted channels should not count against the cap.
+	if err := s.starredRepo.PurgeDeleted(ctx, userID); err != nil {
+		return nil, err
+	}
+	count, err := s.starredRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxStarredChannels {
+		return nil, ErrStarLimitReached
+	}
+
+	maxPos, err := s.starredRepo.GetMaxPosition(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	star := &models.StarredChannel{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		ChannelID: channelID,
+		Position:  maxPos + 1,
+		CreatedAt: time.Now(),
+	}
+	if err := s.starredRepo.Star(ctx, star); err != nil {
+		return nil, err
+	}
+
+	return star, nil
+}
+
+func (s *ChannelService) UnstarChannel(ctx context.Context, channelID, userID string) error {
+	starred, err := s.starredRepo.IsStarred(ctx, userID, channelID)
+	if err != nil {
+		return err
+	}
+	if !starred {
+		return ErrNotStarred
+	}
+	return s.starredRepo.Unstar(ctx, userID, channelID)
+}
+
+func (s *ChannelService) ListStarredChannels(ctx context.Context, userID, workspaceID string) ([]*models.StarredChannelDetails, error) {
+	return s.starredRepo.ListChannelsByUser(ctx, userID, workspaceID)
+}
+
+// ReorderStarredChannels sets the order of all the user's starred channels.
+func (s *ChannelService) ReorderStarredChannels(ctx context.Context, userID string, req *models.ReorderStarredRequest) ([]*models.StarredChannelDetails, error) {
+	if err := s.starredRepo.PurgeDeleted(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.starredRepo.Reorder(ctx, userID, req.ChannelIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidStarOrder
+	}
+
+	return s.starredRepo.ListChannelsByUser(ctx, userID, "")
+}
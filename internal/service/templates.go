@@ -0,0 +1,622 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/quckapp/channel-service/internal/repository"
+)
+
+// ── Channel Templates ──
+
+const (
+	TemplateVisibilityPrivate   = "private"
+	TemplateVisibilityWorkspace = "workspace"
+	TemplateVisibilityGlobal    = "global"
+
+	defaultTemplateListLimit = 50
+	maxTemplateListLimit     = 200
+
+	// pinnedMessageTitle names the bookmarks made from pinned messages,
+	// whose content this service does not store.
+	pinnedMessageTitle = "Pinned message"
+
+	// channelNameKey is the unique key on channels (workspace_id, name).
+	channelNameKey = "unique_channel_name"
+)
+
+// CreateTemplate snapshots a channel into a new template: its type, topic,
+// tabs, settings, role permission overrides and pinned messages (as
+// bookmarks). The snapshot becomes version 1, and the template belongs to
+// the channel's workspace.
+func (s *ChannelService) CreateTemplate(ctx context.Context, channelID, userID string, scope WorkspaceScope, req *models.CreateTemplateRequest) (*models.ChannelTemplate, error) {
+	channel, err := s.getChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.snapshotChannel(ctx, channel, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	visibility := req.Visibility
+	if visibility == "" {
+		visibility = TemplateVisibilityPrivate
+		if req.IsPublic {
+			visibility = TemplateVisibilityGlobal
+		}
+	}
+	if visibility == TemplateVisibilityGlobal && !scope.IsAdminOf(channel.WorkspaceID) {
+		return nil, ErrForbidden
+	}
+
+	tmpl := &models.ChannelTemplate{
+		ID:                 uuid.New().String(),
+		Name:               req.Name,
+		Description:        req.Description,
+		CreatedBy:          userID,
+		WorkspaceID:        channel.WorkspaceID,
+		Visibility:         visibility,
+		ChannelType:        version.ChannelType,
+		DefaultTopic:       version.DefaultTopic,
+		DefaultTabs:        version.DefaultTabs,
+		DefaultSettings:    version.DefaultSettings,
+		DefaultBookmarks:   version.DefaultBookmarks,
+		DefaultPermissions: version.DefaultPermissions,
+		SourceChannelID:    version.SourceChannelID,
+		Version:            1,
+		IsPublic:           visibility == TemplateVisibilityGlobal,
+		UseCount:           0,
+		CreatedAt:          version.CreatedAt,
+		UpdatedAt:          version.CreatedAt,
+	}
+	version.TemplateID = tmpl.ID
+	version.Version = tmpl.Version
+
+	if err := s.templateRepo.Create(ctx, tmpl, version); err != nil {
+		return nil, err
+	}
+
+	s.logActivity(ctx, channelID, userID, ActionTemplateCreated, &tmpl.ID, map[string]interface{}{
+		"name":       tmpl.Name,
+		"visibility": tmpl.Visibility,
+	})
+
+	return tmpl, nil
+}
+
+// CreateTemplateVersion re-snapshots a channel into an existing template as
+// its next version. Channels already created from the template are
+// separate copies and are unaffected, and earlier versions stay available.
+// Only the template's creator may add versions, from channels in the
+// template's workspace.
+func (s *ChannelService) CreateTemplateVersion(ctx context.Context, templateID, userID string, scope WorkspaceScope, req *models.CreateTemplateVersionRequest) (*models.ChannelTemplate, error) {
+	tmpl, err := s.getOwnTemplate(ctx, templateID, userID, scope)
+	if err != nil {
+		return nil, err
+	}
+	channel, err := s.getChannel(ctx, req.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	if channel.WorkspaceID != tmpl.WorkspaceID {
+		return nil, ErrWorkspaceMismatch
+	}
+
+	version, err := s.snapshotChannel(ctx, channel, userID)
+	if err != nil {
+		return nil, err
+	}
+	version.TemplateID = tmpl.ID
+	if err := s.templateRepo.AddVersion(ctx, version); err != nil {
+		return nil, err
+	}
+
+	tmpl.ChannelType = version.ChannelType
+	tmpl.DefaultTopic = version.DefaultTopic
+	tmpl.DefaultTabs = version.DefaultTabs
+	tmpl.DefaultSettings = version.DefaultSettings
+	tmpl.DefaultBookmarks = version.DefaultBookmarks
+	tmpl.DefaultPermissions = version.DefaultPermissions
+	tmpl.SourceChannelID = version.SourceChannelID
+	tmpl.Version = version.Version
+	tmpl.UpdatedAt = version.CreatedAt
+
+	s.logActivity(ctx, req.ChannelID, userID, ActionTemplateVersioned, &tmpl.ID, map[string]interface{}{
+		"name":    tmpl.Name,
+		"version": tmpl.Version,
+	})
+
+	return tmpl, nil
+}
+
+func (s *ChannelService) ListTemplateVersions(ctx context.Context, templateID, userID string, scope WorkspaceScope) ([]*models.ChannelTemplateVersion, error) {
+	if _, err := s.getVisibleTemplate(ctx, templateID, userID, scope); err != nil {
+		return nil, err
+	}
+	versions, err := s.templateRepo.ListVersions(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if versions == nil {
+		versions = []*models.ChannelTemplateVersion{}
+	}
+	return versions, nil
+}
+
+// ListTemplates lists the template catalog visible to filter.UserID,
+// newest first or, with sort "popular", most used first.
+func (s *ChannelService) ListTemplates(ctx context.Context, filter *models.TemplateFilter, limit, offset int) (*models.TemplatePage, error) {
+	switch filter.Sort {
+	case "":
+		filter.Sort = "recent"
+	case "recent", "popular":
+	default:
+		return nil, ErrInvalidTemplateSort
+	}
+	filter.Query = strings.TrimSpace(filter.Query)
+	if limit <= 0 || limit > maxTemplateListLimit {
+		limit = defaultTemplateListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	templates, total, err := s.templateRepo.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if templates == nil {
+		templates = []*models.ChannelTemplate{}
+	}
+
+	return &models.TemplatePage{Templates: templates, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// UpdateTemplate changes a template's name, description or visibility.
+// Only its creator may do so, and only a workspace admin may make it
+// global.
+func (s *ChannelService) UpdateTemplate(ctx context.Context, templateID, userID string, scope WorkspaceScope, req *models.UpdateTemplateRequest) (*models.ChannelTemplate, error) {
+	tmpl, err := s.getOwnTemplate(ctx, templateID, userID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		tmpl.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		tmpl.Description = req.Description
+	}
+	if req.Visibility != nil {
+		if *req.Visibility == TemplateVisibilityGlobal && tmpl.Visibility != TemplateVisibilityGlobal && !scope.IsAdminOf(tmpl.WorkspaceID) {
+			return nil, ErrForbidden
+		}
+		tmpl.Visibility = *req.Visibility
+		tmpl.IsPublic = tmpl.Visibility == TemplateVisibilityGlobal
+	}
+	tmpl.UpdatedAt = time.Now()
+
+	if err := s.templateRepo.Update(ctx, tmpl); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// ApplyTemplate creates a channel from a template version with its type,
+// topic, settings, tabs, bookmarks and permission overrides, making the
+// caller its owner. The channel is created in one transaction, so a failure
+// leaves nothing behind.
+func (s *ChannelService) ApplyTemplate(ctx context.Context, templateID, userID string, scope WorkspaceScope, req *models.ApplyTemplateRequest) (*models.Channel, error) {
+	// Channels can only be created in the workspace the caller's token is for.
+	if req.WorkspaceID != scope.WorkspaceID {
+		return nil, ErrForbidden
+	}
+	name := strings.TrimSpace(req.ChannelName)
+	if name == "" {
+		return nil, ErrChannelNameRequired
+	}
+
+	tmpl, err := s.getVisibleTemplate(ctx, templateID, userID, scope)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.Visibility == TemplateVisibilityWorkspace && tmpl.WorkspaceID != req.WorkspaceID {
+		return nil, ErrWorkspaceMismatch
+	}
+	version := currentTemplateVersion(tmpl)
+	if req.Version != 0 && req.Version != tmpl.Version {
+		version, err = s.templateRepo.GetVersion(ctx, templateID, req.Version)
+		if err != nil {
+			return nil, err
+		}
+		if version == nil {
+			return nil, ErrTemplateVersionNotFound
+		}
+	}
+
+	taken, err := s.channelRepo.NameTaken(ctx, req.WorkspaceID, name, "")
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, ErrChannelNameTaken
+	}
+
+	now := time.Now()
+	channel := &models.Channel{
+		ID:          uuid.New().String(),
+		WorkspaceID: req.WorkspaceID,
+		Name:        name,
+		Type:        version.ChannelType,
+		CreatedBy:   userID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	switch channel.Type {
+	case "":
+		channel.Type = "public"
+	case "public", "private":
+	default:
+		return nil, ErrInvalidTemplate
+	}
+	if version.DefaultTopic != nil {
+		channel.Topic = normalizeTopic(*version.DefaultTopic)
+	}
+
+	applied := &repository.AppliedTemplate{
+		Channel: channel,
+		Owner: &models.ChannelMember{
+			ID:            uuid.New().String(),
+			ChannelID:     channel.ID,
+			UserID:        userID,
+			Role:          "owner",
+			Notifications: "all",
+			JoinedAt:      now,
+		},
+	}
+	if applied.Settings, err = templateSettings(channel.ID, version.DefaultSettings); err != nil {
+		return nil, err
+	}
+	if applied.Tabs, err = templateTabs(channel.ID, userID, version.DefaultTabs); err != nil {
+		return nil, err
+	}
+	if applied.Bookmarks, err = templateBookmarks(channel.ID, userID, version.DefaultBookmarks); err != nil {
+		return nil, err
+	}
+	if applied.Permissions, err = templatePermissions(channel.ID, version.DefaultPermissions); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Apply(ctx, tmpl.ID, applied); err != nil {
+		// NameTaken above is only a fast path; a concurrent create can still
+		// claim the name before the insert.
+		if repository.IsDuplicateKey(err, channelNameKey) {
+			return nil, ErrChannelNameTaken
+		}
+		return nil, err
+	}
+	s.afterMembersAdded(ctx, channel.ID, userID, []*models.ChannelMember{applied.Owner}, "template")
+
+	s.logActivity(ctx, channel.ID, userID, ActionTemplateApplied, &tmpl.ID, map[string]interface{}{
+		"template": tmpl.Name,
+		"version":  version.Version,
+	})
+
+	return channel, nil
+}
+
+// DeleteTemplate deletes a template and its versions. Only its creator may
+// do so; channels created from it are unaffected.
+func (s *ChannelService) DeleteTemplate(ctx context.Context, templateID, userID string, scope WorkspaceScope) error {
+	if _, err := s.getOwnTemplate(ctx, templateID, userID, scope); err != nil {
+		return err
+	}
+	return s.templateRepo.Delete(ctx, templateID)
+}
+
+// getVisibleTemplate returns the template if the caller may see it: it is
+// global, theirs, or shared with the workspace their token is for. Others
+// are reported as not found.
+func (s *ChannelService) getVisibleTemplate(ctx context.Context, templateID, userID string, scope WorkspaceScope) (*models.ChannelTemplate, error) {
+	tmpl, err := s.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl == nil {
+		return nil, ErrChannelTemplateNotFound
+	}
+	switch {
+	case tmpl.Visibility == TemplateVisibilityGlobal, tmpl.CreatedBy == userID:
+	case tmpl.Visibility == TemplateVisibilityWorkspace && tmpl.WorkspaceID == scope.WorkspaceID:
+	default:
+		return nil, ErrChannelTemplateNotFound
+	}
+	return tmpl, nil
+}
+
+// getOwnTemplate returns the template if userID created it.
+func (s *ChannelService) getOwnTemplate(ctx context.Context, templateID, userID string, scope WorkspaceScope) (*models.ChannelTemplate, error) {
+	tmpl, err := s.getVisibleTemplate(ctx, templateID, userID, scope)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.CreatedBy != userID {
+		return nil, ErrForbidden
+	}
+	return tmpl, nil
+}
+
+// currentTemplateVersion returns the template's current contents as a
+// version, without a lookup.
+func currentTemplateVersion(tmpl *models.ChannelTemplate) *models.ChannelTemplateVersion {
+	return &models.ChannelTemplateVersion{
+		TemplateID:         tmpl.ID,
+		Version:            tmpl.Version,
+		ChannelType:        tmpl.ChannelType,
+		DefaultTopic:       tmpl.DefaultTopic,
+		DefaultTabs:        tmpl.DefaultTabs,
+		DefaultSettings:    tmpl.DefaultSettings,
+		DefaultBookmarks:   tmpl.DefaultBookmarks,
+		DefaultPermissions: tmpl.DefaultPermissions,
+		SourceChannelID:    tmpl.SourceChannelID,
+		CreatedBy:          tmpl.CreatedBy,
+		CreatedAt:          tmpl.UpdatedAt,
+	}
+}
+
+// ── Snapshots ──
+
+// snapshotChannel captures a channel's reusable configuration. Only channel
+// admins may snapshot, since the result includes permission overrides.
+// Pinned messages become bookmarks to them; personal bookmarks are left
+// out, since the template may be shared.
+func (s *ChannelService) snapshotChannel(ctx context.Context, channel *models.Channel, userID string) (*models.ChannelTemplateVersion, error) {
+	channelID := channel.ID
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	if channel.Type != "public" && channel.Type != "private" {
+		return nil, ErrTemplateSourceType
+	}
+
+	version := &models.ChannelTemplateVersion{
+		ID:              uuid.New().String(),
+		ChannelType:     channel.Type,
+		DefaultTopic:    channel.Topic,
+		SourceChannelID: &channel.ID,
+		CreatedBy:       userID,
+		CreatedAt:       time.Now(),
+	}
+
+	tabs, err := s.tabRepo.ListByChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	templateTabs := make([]models.TemplateTab, 0, len(tabs))
+	for _, tab := range tabs {
+		templateTabs = append(templateTabs, models.TemplateTab{Name: tab.Name, TabType: tab.TabType, Config: tab.Config})
+	}
+	if version.DefaultTabs, err = marshalTemplateField(templateTabs, len(templateTabs)); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settingsRepo.Get(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if settings != nil {
+		if version.DefaultSettings, err = marshalTemplateField(settingsSnapshot(settings), 1); err != nil {
+			return nil, err
+		}
+	}
+
+	pins, err := s.pinRepo.ListByChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	templateBookmarks := make([]models.TemplateBookmark, 0, len(pins))
+	for _, pin := range pins {
+		templateBookmarks = append(templateBookmarks, models.TemplateBookmark{Title: pinnedMessageTitle, MessageID: pin.MessageID})
+	}
+	if version.DefaultBookmarks, err = marshalTemplateField(templateBookmarks, len(templateBookmarks)); err != nil {
+		return nil, err
+	}
+
+	perms, err := s.permissionRepo.ListByChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	templatePerms := make([]models.TemplatePermission, 0, len(perms))
+	for _, p := range perms {
+		if p.TargetType != "role" {
+			continue
+		}
+		templatePerms = append(templatePerms, models.TemplatePermission{
+			PermissionType: p.PermissionType,
+			TargetType:     p.TargetType,
+			TargetID:       p.TargetID,
+			Allow:          p.Allow,
+			Deny:           p.Deny,
+		})
+	}
+	if version.DefaultPermissions, err = marshalTemplateField(templatePerms, len(templatePerms)); err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// settingsSnapshot expresses settings as an update that sets every field.
+func settingsSnapshot(settings *models.ChannelSetting) *models.UpdateChannelSettingsRequest {
+	return &models.UpdateChannelSettingsRequest{
+		SlowModeInterval:    &settings.SlowModeInterval,
+		MaxPins:             &settings.MaxPins,
+		MaxBookmarks:        &settings.MaxBookmarks,
+		AllowThreads:        &settings.AllowThreads,
+		AllowReactions:      &settings.AllowReactions,
+		AllowInvites:        &settings.AllowInvites,
+		AutoArchiveDays:     &settings.AutoArchiveDays,
+		DefaultNotification: &settings.DefaultNotification,
+		CustomEmoji:         &settings.CustomEmoji,
+		LinkPreviews:        &settings.LinkPreviews,
+		MemberLimit:         &settings.MemberLimit,
+		VoiceStageMode:      &settings.VoiceStageMode,
+	}
+}
+
+// marshalTemplateField encodes v as a template JSON column, leaving the
+// column NULL when there are no entries.
+func marshalTemplateField(v interface{}, entries int) (*string, error) {
+	if entries == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	str := string(data)
+	return &str, nil
+}
+
+// unmarshalTemplateField decodes a template JSON column into v, reporting
+// whether it held anything.
+func unmarshalTemplateField(raw *string, v interface{}) (bool, error) {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(*raw), v); err != nil {
+		return false, ErrInvalidTemplate
+	}
+	return true, nil
+}
+
+// templateSettings builds the new channel's settings row by applying the
+// template's DefaultSettings over the defaults, or returns nil if the
+// template has none.
+func templateSettings(channelID string, raw *string) (*models.ChannelSetting, error) {
+	var req models.UpdateChannelSettingsRequest
+	ok, err := unmarshalTemplateField(raw, &req)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	settings := defaultChannelSettings(channelID)
+	settings.ID = uuid.New().String()
+	setInt := func(dst *int, v *int) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	setBool := func(dst *bool, v *bool) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	setInt(&settings.SlowModeInterval, req.SlowModeInterval)
+	setInt(&settings.MaxPins, req.MaxPins)
+	setInt(&settings.MaxBookmarks, req.MaxBookmarks)
+	setBool(&settings.AllowThreads, req.AllowThreads)
+	setBool(&settings.AllowReactions, req.AllowReactions)
+	setBool(&settings.AllowInvites, req.AllowInvites)
+	setInt(&settings.AutoArchiveDays, req.AutoArchiveDays)
+	if req.DefaultNotification != nil {
+		settings.DefaultNotification = *req.DefaultNotification
+	}
+	setBool(&settings.CustomEmoji, req.CustomEmoji)
+	setBool(&settings.LinkPreviews, req.LinkPreviews)
+	setInt(&settings.MemberLimit, req.MemberLimit)
+	setBool(&settings.VoiceStageMode, req.VoiceStageMode)
+
+	return settings, nil
+}
+
+// templateTabs builds the new channel's tabs from DefaultTabs, in order.
+func templateTabs(channelID, userID string, raw *string) ([]*models.ChannelTab, error) {
+	var defaults []models.TemplateTab
+	if _, err := unmarshalTemplateField(raw, &defaults); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tabs := make([]*models.ChannelTab, 0, len(defaults))
+	for i, d := range defaults {
+		if d.Name == "" || d.TabType == "" {
+			return nil, ErrInvalidTemplate
+		}
+		tabs = append(tabs, &models.ChannelTab{
+			ID:        uuid.New().String(),
+			ChannelID: channelID,
+			Name:      d.Name,
+			TabType:   d.TabType,
+			Config:    d.Config,
+			Position:  i,
+			CreatedBy: userID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+	return tabs, nil
+}
+
+// templateBookmarks gives the new channel's owner a bookmark to each of the
+// template's pinned messages.
+func templateBookmarks(channelID, userID string, raw *string) ([]*models.ChannelBookmark, error) {
+	var defaults []models.TemplateBookmark
+	if _, err := unmarshalTemplateField(raw, &defaults); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bookmarks := make([]*models.ChannelBookmark, 0, len(defaults))
+	for i, d := range defaults {
+		if d.Title == "" || d.MessageID == "" {
+			return nil, ErrInvalidTemplate
+		}
+		entityType, entityID := "message", d.MessageID
+		bookmarks = append(bookmarks, &models.ChannelBookmark{
+			ID:         uuid.New().String(),
+			ChannelID:  channelID,
+			UserID:     userID,
+			Title:      d.Title,
+			EntityType: &entityType,
+			EntityID:   &entityID,
+			Position:   i,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		})
+	}
+	return bookmarks, nil
+}
+
+// templatePermissions builds the new channel's role permission overrides.
+func templatePermissions(channelID string, raw *string) ([]*models.ChannelPermission, error) {
+	var defaults []models.TemplatePermission
+	if _, err := unmarshalTemplateField(raw, &defaults); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	perms := make([]*models.ChannelPermission, 0, len(defaults))
+	for _, d := range defaults {
+		if d.PermissionType == "" || d.TargetType != "role" || d.TargetID == "" {
+			return nil, ErrInvalidTemplate
+		}
+		perms = append(perms, &models.ChannelPermission{
+			ID:             uuid.New().String(),
+			ChannelID:      channelID,
+			PermissionType: d.PermissionType,
+			TargetType:     d.TargetType,
+			TargetID:       d.TargetID,
+			Allow:          d.Allow,
+			Deny:           d.Deny,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+	return perms, nil
+}
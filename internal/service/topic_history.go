@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Channel Details & Topic History ──
+
+const (
+	ActionChannelUpdated = "channel.updated"
+	ActionTopicChanged   = "channel.topic_changed"
+	ActionTopicReverted  = "channel.topic_reverted"
+
+	defaultTopicHistoryLimit = 20
+	maxTopicHistoryLimit     = 100
+)
+
+// UpdateChannel changes a channel's name, description or topic. Topic
+// changes are recorded in the topic history.
+func (s *ChannelService) UpdateChannel(ctx context.Context, channelID, userID string, req *models.UpdateChannelRequest) (*models.Channel, error) {
+	channel, err := s.getChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if channel.IsArchived {
+		return nil, ErrChannelArchived
+	}
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]models.SettingChange)
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name != channel.Name {
+			taken, err := s.channelRepo.NameTaken(ctx, channel.WorkspaceID, name, channelID)
+			if err != nil {
+				return nil, err
+			}
+			if taken {
+				return nil, ErrChannelNameTaken
+			}
+			changes["name"] = models.SettingChange{Old: channel.Name, New: name}
+			channel.Name = name
+		}
+	}
+	if req.Description != nil && (channel.Description == nil || *channel.Description != *req.Description) {
+		changes["description"] = models.SettingChange{Old: channel.Description, New: *req.Description}
+		channel.Description = req.Description
+	}
+	if req.Topic != nil {
+		channel.Topic = normalizeTopic(*req.Topic)
+	}
+
+	topicChanged, err := s.saveChannel(ctx, channel, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) > 0 {
+		s.logActivity(ctx, channelID, userID, ActionChannelUpdated, nil, map[string]interface{}{"changes": changes})
+	}
+	if topicChanged {
+		s.logActivity(ctx, channelID, userID, ActionTopicChanged, nil, map[string]interface{}{"topic": channel.Topic})
+	}
+
+	return channel, nil
+}
+
+// normalizeTopic treats a blank topic as clearing it.
+func normalizeTopic(topic string) *string {
+	topic = strings.TrimSpace(topic)
+	if topic == "" {
+		return nil
+	}
+	return &topic
+}
+
+// saveChannel persists channel, recording a topic history entry in the same
+// transaction if the topic changed.
+func (s *ChannelService) saveChannel(ctx context.Context, channel *models.Channel, userID string) (bool, error) {
+	entry := &models.TopicHistory{
+		ID:        uuid.New().String(),
+		ChangedBy: userID,
+		ChangedAt: time.Now(),
+	}
+	changed, err := s.channelRepo.Update(ctx, channel, entry)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, ErrChannelNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+	channel.UpdatedAt = entry.ChangedAt
+	return changed, nil
+}
+
+// GetTopicHistory lists topic changes, newest first.
+func (s *ChannelService) GetTopicHistory(ctx context.Context, channelID, userID string, limit, offset int) (*models.TopicHistoryPage, error) {
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxTopicHistoryLimit {
+		limit = defaultTopicHistoryLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	history, err := s.topicHistoryRepo.ListByChannel(ctx, channelID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.topicHistoryRepo.CountByChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		history = []*models.TopicHistory{}
+	}
+
+	return &models.TopicHistoryPage{History: history, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// RevertTopic restores the topic that a history entry replaced, defaulting
+// to the most recent change. The revert is itself recorded in the history.
+func (s *ChannelService) RevertTopic(ctx context.Context, channelID, userID string, req *models.RevertTopicRequest) (*models.Channel, error) {
+	channel, err := s.getChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if channel.IsArchived {
+		return nil, ErrChannelArchived
+	}
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	var entry *models.TopicHistory
+	if req.HistoryID != nil && *req.HistoryID != "" {
+		entry, err = s.topicHistoryRepo.GetByID(ctx, *req.HistoryID)
+	} else {
+		entry, err = s.topicHistoryRepo.GetLatest(ctx, channelID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || entry.ChannelID != channelID {
+		return nil, ErrTopicHistoryNotFound
+	}
+
+	channel.Topic = entry.OldTopic
+	changed, err := s.saveChannel(ctx, channel, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if changed {
+		s.logActivity(ctx, channelID, userID, ActionTopicReverted, &entry.ID, map[string]interface{}{"topic": channel.Topic})
+	}
+
+	return channel, nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Voice ──
+
+const (
+	TopicVoiceJoined       = "channel.voice.joined"
+	TopicVoiceLeft         = "channel.voice.left"
+	TopicVoiceStateUpdated = "channel.voice.updated"
+)
+
+// JoinVoiceChannel connects the user to the channel's voice session. A user
+// is active in one voice channel at a time, so joining disconnects them from
+// any other. Joining a channel they are already in returns the existing
+// session unchanged. In stage mode admins join as speakers and everyone
+// else joins muted in the audience.
+func (s *ChannelService) JoinVoiceChannel(ctx context.Context, channelID, userID string, req *models.UpdateVoiceStateRequest) (*models.VoiceChannelState, error) {
+	channel, err := s.getChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if channel.IsArchived {
+		return nil, ErrChannelArchived
+	}
+
+	state := &models.VoiceChannelState{
+		ID:        uuid.New().String(),
+		ChannelID: channelID,
+		UserID:    userID,
+		JoinedAt:  time.Now(),
+	}
+	if req != nil {
+		state.IsMuted = boolValue(req.IsMuted)
+		state.IsDeafened = boolValue(req.IsDeafened)
+		state.IsScreenShare = boolValue(req.IsScreenShare)
+		state.IsVideoOn = boolValue(req.IsVideoOn)
+	}
+	if err := s.applyStageRole(ctx, state); err != nil {
+		return nil, err
+	}
+
+	left, joined, err := s.voiceRepo.Join(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if !joined {
+		return s.voiceRepo.GetState(ctx, channelID, userID)
+	}
+
+	s.touchVoiceHeartbeat(ctx, state.ID, state.JoinedAt)
+	for _, prev := range left {
+		s.clearVoiceHeartbeats(ctx, prev.ID)
+		s.publishVoiceEvent(ctx, TopicVoiceLeft, prev)
+	}
+	s.publishVoiceEvent(ctx, TopicVoiceJoined, state)
+
+	return state, nil
+}
+
+func (s *ChannelService) LeaveVoiceChannel(ctx context.Context, channelID, userID string) error {
+	state, err := s.voiceRepo.GetState(ctx, channelID, userID)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return ErrNotInVoiceChannel
+	}
+
+	left, err := s.voiceRepo.Leave(ctx, channelID, userID)
+	if err != nil {
+		return err
+	}
+	if !left {
+		return ErrNotInVoiceChannel
+	}
+
+	now := time.Now()
+	state.DisconnectedAt = &now
+	s.clearVoiceHeartbeats(ctx, state.ID)
+	s.publishVoiceEvent(ctx, TopicVoiceLeft, state)
+	return nil
+}
+
+// UpdateVoiceState changes the user's mute, deafen, screen share or video
+// flags in their active session. In stage mode only speakers may unmute.
+func (s *ChannelService) UpdateVoiceState(ctx context.Context, channelID, userID string, req *models.UpdateVoiceStateRequest) (*models.VoiceChannelState, error) {
+	state, err := s.voiceRepo.GetState(ctx, channelID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, ErrNotInVoiceChannel
+	}
+	if req.IsMuted != nil && !*req.IsMuted && !state.IsSpeaker {
+		settings, err := s.getChannelSettings(ctx, channelID)
+		if err != nil {
+			return nil, err
+		}
+		if settings.VoiceStageMode {
+			return nil, ErrVoiceStageAudience
+		}
+	}
+
+	if err := s.voiceRepo.UpdateState(ctx, channelID, userID, req); err != nil {
+		return nil, err
+	}
+	if req.IsMuted != nil {
+		state.IsMuted = *req.IsMuted
+	}
+	if req.IsDeafened != nil {
+		state.IsDeafened = *req.IsDeafened
+	}
+	if req.IsScreenShare != nil {
+		state.IsScreenShare = *req.IsScreenShare
+	}
+	if req.IsVideoOn != nil {
+		state.IsVideoOn = *req.IsVideoOn
+	}
+
+	s.publishVoiceEvent(ctx, TopicVoiceStateUpdated, state)
+	return state, nil
+}
+
+// ListVoiceParticipants lists the channel's connected users, earliest first.
+func (s *ChannelService) ListVoiceParticipants(ctx context.Context, channelID, userID string) ([]*models.VoiceChannelState, error) {
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	participants, err := s.voiceRepo.ListParticipants(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if participants == nil {
+		participants = []*models.VoiceChannelState{}
+	}
+	return participants, nil
+}
+
+// applyStageRole checks that the user may join the state's channel and, if
+// the channel is in stage mode, makes admins speakers and mutes everyone
+// else.
+func (s *ChannelService) applyStageRole(ctx context.Context, state *models.VoiceChannelState) error {
+	role, err := s.memberRepo.GetRole(ctx, state.ChannelID, state.UserID)
+	if err == sql.ErrNoRows {
+		return ErrNotChannelMember
+	}
+	if err != nil {
+		return err
+	}
+	settings, err := s.getChannelSettings(ctx, state.ChannelID)
+	if err != nil {
+		return err
+	}
+
+	state.IsSpeaker = !settings.VoiceStageMode || role == "owner" || role == "admin"
+	if !state.IsSpeaker {
+		state.IsMuted = true
+	}
+	state.HandRaisedAt = nil
+	return nil
+}
+
+func (s *ChannelService) publishVoiceEvent(ctx context.Context, topic string, state *models.VoiceChannelState) {
+	s.publishModeratedVoiceEvent(ctx, topic, state, "")
+}
+
+// publishModeratedVoiceEvent publishes a voice event caused by actorID
+// rather than by the participant themselves.
+func (s *ChannelService) publishModeratedVoiceEvent(ctx context.Context, topic string, state *models.VoiceChannelState, actorID string) {
+	s.publishEvent(ctx, topic, state.ChannelID, models.VoiceStateEvent{
+		Type:       topic,
+		ChannelID:  state.ChannelID,
+		UserID:     state.UserID,
+		ActorID:    actorID,
+		State:      state,
+		OccurredAt: time.Now(),
+	})
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
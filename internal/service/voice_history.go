@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Voice History & Reports ──
+
+const (
+	defaultVoiceHistoryLimit = 50
+	maxVoiceHistoryLimit     = 200
+)
+
+// ListChannelVoiceSessions lists the channel's voice sessions, newest
+// first, optionally narrowed to one participant.
+func (s *ChannelService) ListChannelVoiceSessions(ctx context.Context, channelID, userID string, filter *models.VoiceSessionFilter, limit, offset int) (*models.VoiceSessionPage, error) {
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	filter.ChannelID = channelID
+	return s.listVoiceSessions(ctx, filter, limit, offset)
+}
+
+// ListUserVoiceSessions lists the user's own voice sessions across all
+// channels, newest first.
+func (s *ChannelService) ListUserVoiceSessions(ctx context.Context, userID string, filter *models.VoiceSessionFilter, limit, offset int) (*models.VoiceSessionPage, error) {
+	filter.ChannelID = ""
+	filter.UserID = userID
+	return s.listVoiceSessions(ctx, filter, limit, offset)
+}
+
+func (s *ChannelService) listVoiceSessions(ctx context.Context, filter *models.VoiceSessionFilter, limit, offset int) (*models.VoiceSessionPage, error) {
+	if filter.From.IsZero() {
+		filter.From = time.Unix(0, 0)
+	}
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, ErrInvalidTimeRange
+	}
+	if limit <= 0 || limit > maxVoiceHistoryLimit {
+		limit = defaultVoiceHistoryLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	sessions, total, err := s.voiceRepo.ListSessions(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if sessions == nil {
+		sessions = []*models.VoiceChannelState{}
+	}
+
+	return &models.VoiceSessionPage{Sessions: sessions, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// GetVoiceUsageReport reports minutes per day, peak concurrency and average
+// session length. The range defaults to the last 30 days.
+func (s *ChannelService) GetVoiceUsageReport(ctx context.Context, channelID, userID string, from, to time.Time) (*models.VoiceUsageReport, error) {
+	now := time.Now()
+	if to.IsZero() {
+		to = now
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+	if !from.Before(to) || to.Sub(from) > maxTimeSeriesDays*24*time.Hour {
+		return nil, ErrInvalidTimeRange
+	}
+	if err := s.requireMember(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+
+	report, err := s.voiceRepo.GetUsageReport(ctx, channelID, from, to, now)
+	if err != nil {
+		return nil, err
+	}
+	if report.Daily == nil {
+		report.Daily = []*models.VoiceUsageDay{}
+	}
+	return report, nil
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+// ── Voice Moderation ──
+
+const (
+	ModerationVoiceServerMute     = "voice.server_mute"
+	ModerationVoiceServerUnmute   = "voice.server_unmute"
+	ModerationVoiceServerDeafen   = "voice.server_deafen"
+	ModerationVoiceServerUndeafen = "voice.server_undeafen"
+	ModerationVoiceSpeakerAdded   = "voice.speaker_added"
+	ModerationVoiceSpeakerRemoved = "voice.speaker_removed"
+	ModerationVoiceDisconnect     = "voice.disconnect"
+	ModerationVoiceMove           = "voice.move"
+)
+
+func (s *ChannelService) getVoiceParticipant(ctx context.Context, channelID, userID string) (*models.VoiceChannelState, error) {
+	state, err := s.voiceRepo.GetState(ctx, channelID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, ErrNotInVoiceChannel
+	}
+	return state, nil
+}
+
+// applyStageMode updates the sessions already in the channel after its stage
+// mode setting changed, as if everyone had rejoined. Failures are logged;
+// the settings change itself has already been saved.
+func (s *ChannelService) applyStageMode(ctx context.Context, channelID, userID string, stage bool) {
+	changed, err := s.voiceRepo.ApplyStageMode(ctx, channelID, stage)
+	if err != nil {
+		s.logger.WithError(err).WithField("channel_id", channelID).Warn("Failed to apply stage mode to active voice sessions")
+		return
+	}
+	for _, state := range changed {
+		s.publishModeratedVoiceEvent(ctx, TopicVoiceStateUpdated, state, userID)
+	}
+}
+
+// ModerateVoiceParticipant server-mutes or deafens a participant, or adds
+// or removes them as a stage speaker. Removed speakers are muted.
+func (s *ChannelService) ModerateVoiceParticipant(ctx context.Context, channelID, targetUserID, userID string, req *models.ModerateVoiceRequest) (*models.VoiceChannelState, error) {
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	state, err := s.getVoiceParticipant(ctx, channelID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []string
+	if req.ServerMuted != nil && *req.ServerMuted != state.IsServerMuted {
+		state.IsServerMuted = *req.ServerMuted
+		actions = append(actions, pickAction(state.IsServerMuted, ModerationVoiceServerMute, ModerationVoiceServerUnmute))
+	}
+	if req.ServerDeafened != nil && *req.ServerDeafened != state.IsServerDeafened {
+		state.IsServerDeafened = *req.ServerDeafened
+		actions = append(actions, pickAction(state.IsServerDeafened, ModerationVoiceServerDeafen, ModerationVoiceServerUndeafen))
+	}
+	if req.Speaker != nil && *req.Speaker != state.IsSpeaker {
+		state.IsSpeaker = *req.Speaker
+		state.HandRaisedAt = nil
+		if !state.IsSpeaker {
+			state.IsMuted = true
+		}
+		actions = append(actions, pickAction(state.IsSpeaker, ModerationVoiceSpeakerAdded, ModerationVoiceSpeakerRemoved))
+	}
+	if len(actions) == 0 {
+		return state, nil
+	}
+
+	updated, err := s.voiceRepo.UpdateModeration(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrNotInVoiceChannel
+	}
+
+	for _, action := range actions {
+		s.logModeration(ctx, channelID, targetUserID, userID, action, req.Reason)
+	}
+	s.publishModeratedVoiceEvent(ctx, TopicVoiceStateUpdated, state, userID)
+
+	return state, nil
+}
+
+// DisconnectVoiceParticipant removes a participant from the channel's voice
+// session.
+func (s *ChannelService) DisconnectVoiceParticipant(ctx context.Context, channelID, targetUserID, userID string, req *models.DisconnectVoiceRequest) error {
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return err
+	}
+	state, err := s.getVoiceParticipant(ctx, channelID, targetUserID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	disconnected, err := s.voiceRepo.Disconnect(ctx, state.ID, now)
+	if err != nil {
+		return err
+	}
+	if !disconnected {
+		return ErrNotInVoiceChannel
+	}
+
+	state.DisconnectedAt = &now
+	s.clearVoiceHeartbeats(ctx, state.ID)
+	s.logModeration(ctx, channelID, targetUserID, userID, ModerationVoiceDisconnect, req.Reason)
+	s.publishModeratedVoiceEvent(ctx, TopicVoiceLeft, state, userID)
+	return nil
+}
+
+// MoveVoiceParticipant moves a participant to another voice channel in the
+// same workspace. The caller must administer both channels and the
+// participant must be a member of the target. Server mute and deafen carry
+// over; stage roles are reassigned for the target channel.
+func (s *ChannelService) MoveVoiceParticipant(ctx context.Context, channelID, targetUserID, userID string, req *models.MoveVoiceRequest) (*models.VoiceChannelState, error) {
+	source, err := s.getChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getChannel(ctx, req.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	if target.WorkspaceID != source.WorkspaceID {
+		return nil, ErrWorkspaceMismatch
+	}
+	if target.IsArchived {
+		return nil, ErrChannelArchived
+	}
+	if err := s.requireChannelAdmin(ctx, channelID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.requireChannelAdmin(ctx, target.ID, userID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.getVoiceParticipant(ctx, channelID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if target.ID == channelID {
+		return current, nil
+	}
+
+	state := &models.VoiceChannelState{
+		ID:               uuid.New().String(),
+		ChannelID:        target.ID,
+		UserID:           targetUserID,
+		IsMuted:          current.IsMuted,
+		IsDeafened:       current.IsDeafened,
+		IsScreenShare:    current.IsScreenShare,
+		IsVideoOn:        current.IsVideoOn,
+		IsServerMuted:    current.IsServerMuted,
+		IsServerDeafened: current.IsServerDeafened,
+		JoinedAt:         time.Now(),
+	}
+	if err := s.applyStageRole(ctx, state); err != nil {
+		return nil, err
+	}
+
+	left, joined, err := s.voiceRepo.Join(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if !joined {
+		return s.voiceRepo.GetState(ctx, target.ID, targetUserID)
+	}
+
+	s.touchVoiceHeartbeat(ctx, state.ID, state.JoinedAt)
+	for _, prev := range left {
+		s.clearVoiceHeartbeats(ctx, prev.ID)
+		s.publishModeratedVoiceEvent(ctx, TopicVoiceLeft, prev, userID)
+	}
+	s.logModeration(ctx, channelID, targetUserID, userID, ModerationVoiceMove, req.Reason)
+	s.publishModeratedVoiceEvent(ctx, TopicVoiceJoined, state, userID)
+
+	return state, nil
+}
+
+// SetHandRaised raises or lowers the user's hand on a stage. Speakers have
+// nothing to ask for, so their request is a no-op.
+func (s *ChannelService) SetHandRaised(ctx context.Context, channelID, userID string, raised bool) (*models.VoiceChannelState, error) {
+	state, err := s.getVoiceParticipant(ctx, channelID, userID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.getChannelSettings(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.VoiceStageMode {
+		return nil, ErrVoiceStageModeOff
+	}
+	if state.IsSpeaker || raised == (state.HandRaisedAt != nil) {
+		return state, nil
+	}
+
+	if raised {
+		now := time.Now()
+		state.HandRaisedAt = &now
+	} else {
+		state.HandRaisedAt = nil
+	}
+	updated, err := s.voiceRepo.UpdateModeration(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrNotInVoiceChannel
+	}
+
+	s.publishVoiceEvent(ctx, TopicVoiceStateUpdated, state)
+	return state, nil
+}
+
+// logModeration records a moderation action. The action has already taken
+// effect, so a failure is logged rather than returned.
+func (s *ChannelService) logModeration(ctx context.Context, channelID, targetUserID, actorID, action string, reason *string) {
+	entry := &models.ModerationEntry{
+		ID:        uuid.New().String(),
+		ChannelID: channelID,
+		UserID:    targetUserID,
+		Action:    action,
+		ActorID:   actorID,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+	if err := s.moderationRepo.LogAction(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("action", action).Warn("Failed to log moderation action")
+	}
+}
+
+func pickAction(on bool, onAction, offAction string) string {
+	if on {
+		return onAction
+	}
+	return offAction
+}
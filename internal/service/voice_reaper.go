@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// ── Voice Heartbeats ──
+
+const (
+	DefaultVoiceHeartbeatTimeout = 90 * time.Second
+
+	// voiceHeartbeatKey is a Redis hash of session ID to last-seen Unix
+	// milliseconds.
+	voiceHeartbeatKey = "voice:heartbeats"
+
+	// voiceReaperPageSize bounds the sessions checked per query.
+	voiceReaperPageSize = 200
+)
+
+// VoiceHeartbeat records that the user's client in the channel is still
+// connected. Clients should send one well within the heartbeat timeout.
+func (s *ChannelService) VoiceHeartbeat(ctx context.Context, channelID, userID string) error {
+	state, err := s.voiceRepo.GetState(ctx, channelID, userID)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return ErrNotInVoiceChannel
+	}
+	if s.redis == nil {
+		return nil
+	}
+	return s.redis.HSet(ctx, voiceHeartbeatKey, state.ID, time.Now().UnixMilli()).Err()
+}
+
+func (s *ChannelService) touchVoiceHeartbeat(ctx context.Context, sessionID string, at time.Time) {
+	if s.redis == nil {
+		return
+	}
+	if err := s.redis.HSet(ctx, voiceHeartbeatKey, sessionID, at.UnixMilli()).Err(); err != nil {
+		s.logger.WithError(err).WithField("session_id", sessionID).Warn("Failed to record voice heartbeat")
+	}
+}
+
+func (s *ChannelService) clearVoiceHeartbeats(ctx context.Context, sessionIDs ...string) {
+	if s.redis == nil || len(sessionIDs) == 0 {
+		return
+	}
+	if err := s.redis.HDel(ctx, voiceHeartbeatKey, sessionIDs...).Err(); err != nil {
+		s.logger.WithError(err).Warn("Failed to clear voice heartbeats")
+	}
+}
+
+// RunVoiceReaper disconnects voice sessions whose last heartbeat is older
+// than timeout, so crashed clients do not appear connected forever. It runs
+// every interval until ctx is cancelled. Heartbeats live in Redis, so the
+// reaper does nothing without it.
+func (s *ChannelService) RunVoiceReaper(ctx context.Context, interval, timeout time.Duration) {
+	if s.redis == nil {
+		s.logger.Warn("Redis unavailable, voice session reaper disabled")
+		return
+	}
+	if timeout <= 0 {
+		timeout = DefaultVoiceHeartbeatTimeout
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sweepStaleVoiceSessions(ctx, timeout); err != nil && ctx.Err() == nil {
+			s.logger.WithError(err).Error("Voice session reaper sweep failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweepStaleVoiceSessions checks every session old enough to have missed
+// its heartbeats. A session with no heartbeat on record is judged by its
+// join time.
+func (s *ChannelService) sweepStaleVoiceSessions(ctx context.Context, timeout time.Duration) error {
+	cutoff := time.Now().Add(-timeout)
+
+	afterID := ""
+	for {
+		states, err := s.voiceRepo.ListActiveJoinedBefore(ctx, cutoff, afterID, voiceReaperPageSize)
+		if err != nil {
+			return err
+		}
+		if len(states) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(states))
+		for i, state := range states {
+			ids[i] = state.ID
+		}
+		lastSeen, err := s.redis.HMGet(ctx, voiceHeartbeatKey, ids...).Result()
+		if err != nil {
+			return err
+		}
+
+		for i, state := range states {
+			seenAt := state.JoinedAt
+			if v, ok := lastSeen[i].(string); ok {
+				if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+					seenAt = time.UnixMilli(ms)
+				}
+			}
+			if seenAt.After(cutoff) {
+				continue
+			}
+			if err := s.reapVoiceSession(ctx, state, seenAt); err != nil {
+				s.logger.WithError(err).WithFields(logrus.Fields{
+					"channel_id": state.ChannelID,
+					"user_id":    state.UserID,
+					"session_id": state.ID,
+				}).Error("Failed to disconnect stale voice session")
+			}
+		}
+
+		if len(states) < voiceReaperPageSize || ctx.Err() != nil {
+			return ctx.Err()
+		}
+		afterID = states[len(states)-1].ID
+	}
+}
+
+// reapVoiceSession ends the session as of its last heartbeat, which keeps
+// session durations accurate.
+func (s *ChannelService) reapVoiceSession(ctx context.Context, state *models.VoiceChannelState, lastSeen time.Time) error {
+	disconnected, err := s.voiceRepo.Disconnect(ctx, state.ID, lastSeen)
+	if err != nil {
+		return err
+	}
+	s.clearVoiceHeartbeats(ctx, state.ID)
+	if !disconnected {
+		return nil
+	}
+
+	state.DisconnectedAt = &lastSeen
+	s.publishVoiceEvent(ctx, TopicVoiceLeft, state)
+	return nil
+}
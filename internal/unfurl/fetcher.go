@@ -0,0 +1,236 @@
+// Package unfurl fetches OpenGraph and oEmbed metadata for link previews.
+package unfurl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+)
+
+var (
+	ErrInvalidURL         = errors.New("invalid url")
+	ErrBlockedAddress     = errors.New("address not allowed")
+	ErrUnsupportedContent = errors.New("unsupported content type")
+	ErrTooLarge           = errors.New("response too large")
+	ErrBadStatus          = errors.New("unexpected response status")
+)
+
+const (
+	DefaultTimeout  = 5 * time.Second
+	DefaultMaxBytes = 1 << 20
+	maxRedirects    = 5
+	userAgent       = "QuckAppBot/1.0 (+link preview)"
+)
+
+// Fetcher resolves a URL into a link preview.
+type Fetcher interface {
+	Fetch(ctx context.Context, rawURL string) (*models.LinkPreview, error)
+}
+
+// Options configures an HTTPFetcher.
+type Options struct {
+	Timeout  time.Duration
+	MaxBytes int64
+	// AllowPrivate disables the private address check. It exists for tests
+	// against local httptest servers and must stay off in production.
+	AllowPrivate bool
+}
+
+// HTTPFetcher fetches pages over HTTP and extracts OpenGraph tags, falling
+// back to a discovered oEmbed endpoint for fields the page does not set.
+type HTTPFetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+func NewHTTPFetcher(opts Options) *HTTPFetcher {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultMaxBytes
+	}
+
+	dialer := &net.Dialer{Timeout: opts.Timeout}
+	if !opts.AllowPrivate {
+		// Checking at dial time covers every redirect hop and the address
+		// actually connected to, so DNS rebinding cannot slip past.
+		dialer.Control = denyPrivate
+	}
+
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   opts.Timeout,
+		ResponseHeaderTimeout: opts.Timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &HTTPFetcher{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   opts.Timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return ErrInvalidURL
+				}
+				return nil
+			},
+		},
+		maxBytes: opts.MaxBytes,
+	}
+}
+
+func denyPrivate(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || isPrivateIP(ip) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
+
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isPrivateIP(ip net.IP) bool {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) (*models.LinkPreview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	resp, err := f.get(ctx, u.String(), "text/html,application/xhtml+xml;q=0.9,image/*;q=0.8")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	preview := &models.LinkPreview{URL: rawURL, FetchedAt: time.Now()}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+	case strings.HasPrefix(mediaType, "image/"):
+		preview.Type = "image"
+		preview.ImageURL = resp.Request.URL.String()
+		return preview, nil
+	default:
+		return nil, ErrUnsupportedContent
+	}
+
+	// Metadata lives in <head>, so a truncated page still parses fine.
+	meta := parseHTML(io.LimitReader(resp.Body, f.maxBytes), resp.Request.URL)
+	meta.apply(preview)
+
+	if meta.oembedURL != "" && needsOEmbed(preview) {
+		if oe, err := f.fetchOEmbed(ctx, meta.oembedURL); err == nil {
+			oe.apply(preview)
+		}
+	}
+
+	if preview.Type == "" {
+		preview.Type = "link"
+	}
+	return preview, nil
+}
+
+// needsOEmbed reports whether the page left fields an oEmbed response fills.
+func needsOEmbed(p *models.LinkPreview) bool {
+	return p.Title == "" || p.ImageURL == "" || p.AuthorName == ""
+}
+
+type oembedResponse struct {
+	Type         string `json:"type"`
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+func (o *oembedResponse) apply(p *models.LinkPreview) {
+	if p.Title == "" {
+		p.Title = o.Title
+	}
+	if p.Type == "" {
+		p.Type = o.Type
+	}
+	if p.SiteName == "" {
+		p.SiteName = o.ProviderName
+	}
+	if p.ImageURL == "" {
+		p.ImageURL = o.ThumbnailURL
+	}
+	if p.AuthorName == "" {
+		p.AuthorName = o.AuthorName
+	}
+}
+
+func (f *HTTPFetcher) fetchOEmbed(ctx context.Context, endpoint string) (*oembedResponse, error) {
+	resp, err := f.get(ctx, endpoint, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > f.maxBytes {
+		return nil, ErrTooLarge
+	}
+
+	var oe oembedResponse
+	if err := json.Unmarshal(body, &oe); err != nil {
+		return nil, err
+	}
+	return &oe, nil
+}
+
+func (f *HTTPFetcher) get(ctx context.Context, rawURL, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", accept)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlockedAddress) {
+			return nil, ErrBlockedAddress
+		}
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, ErrBadStatus
+	}
+	return resp, nil
+}
@@ -0,0 +1,244 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newTestFetcher(maxBytes int64) *HTTPFetcher {
+	return NewHTTPFetcher(Options{MaxBytes: maxBytes, AllowPrivate: true})
+}
+
+func TestFetchBlocksPrivateAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><title>internal</title></head></html>`)
+	}))
+	defer srv.Close()
+
+	f := NewHTTPFetcher(Options{})
+	if _, err := f.Fetch(context.Background(), srv.URL); !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("Fetch(%s) error = %v, want %v", srv.URL, err, ErrBlockedAddress)
+	}
+
+	preview, err := newTestFetcher(0).Fetch(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch with AllowPrivate: %v", err)
+	}
+	if preview.Title != "internal" {
+		t.Errorf("Title = %q, want %q", preview.Title, "internal")
+	}
+}
+
+func TestFetchBlocksRedirectToPrivateAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://127.0.0.1:1/", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	f := NewHTTPFetcher(Options{})
+	if _, err := f.Fetch(context.Background(), srv.URL); !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("Fetch error = %v, want %v", err, ErrBlockedAddress)
+	}
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	tests := []struct {
+		ip      string
+		private bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"fe80::1", true},
+		{"fd00::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"8.8.8.8", false},
+		{"100.128.0.1", false},
+		{"2606:4700::1111", false},
+	}
+	for _, tt := range tests {
+		if got := isPrivateIP(net.ParseIP(tt.ip)); got != tt.private {
+			t.Errorf("isPrivateIP(%s) = %v, want %v", tt.ip, got, tt.private)
+		}
+	}
+}
+
+func TestFetchRedirectLimit(t *testing.T) {
+	// /hop/N redirects N more times before serving the page.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hop/"))
+		if n > 0 {
+			http.Redirect(w, r, fmt.Sprintf("/hop/%d", n-1), http.StatusFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><title>landed</title></head></html>`)
+	}))
+	defer srv.Close()
+
+	f := newTestFetcher(0)
+	preview, err := f.Fetch(context.Background(), fmt.Sprintf("%s/hop/%d", srv.URL, maxRedirects-1))
+	if err != nil {
+		t.Fatalf("Fetch within redirect limit: %v", err)
+	}
+	if preview.Title != "landed" {
+		t.Errorf("Title = %q, want %q", preview.Title, "landed")
+	}
+
+	if _, err := f.Fetch(context.Background(), fmt.Sprintf("%s/hop/%d", srv.URL, maxRedirects)); err == nil {
+		t.Fatal("Fetch past redirect limit succeeded, want error")
+	}
+}
+
+func TestFetchRedirectToUnsupportedScheme(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "ftp://example.com/file", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	if _, err := newTestFetcher(0).Fetch(context.Background(), srv.URL); !errors.Is(err, ErrInvalidURL) {
+		t.Fatalf("Fetch error = %v, want %v", err, ErrInvalidURL)
+	}
+}
+
+func TestFetchSizeLimit(t *testing.T) {
+	const maxBytes = 256
+	head := `<html><head><meta property="og:title" content="kept">`
+	padding := strings.Repeat(" ", maxBytes)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, head+padding+`<meta property="og:description" content="dropped"></head></html>`)
+	})
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"title":"%s"}`, strings.Repeat("x", maxBytes))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	f := newTestFetcher(maxBytes)
+	preview, err := f.Fetch(context.Background(), srv.URL+"/page")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if preview.Title != "kept" {
+		t.Errorf("Title = %q, want %q", preview.Title, "kept")
+	}
+	if preview.Description != "" {
+		t.Errorf("Description = %q, want it cut off by the size limit", preview.Description)
+	}
+
+	if _, err := f.fetchOEmbed(context.Background(), srv.URL+"/oembed"); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("fetchOEmbed error = %v, want %v", err, ErrTooLarge)
+	}
+}
+
+func TestFetchRejectsUnsupportedContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		fmt.Fprint(w, "%PDF-1.4")
+	}))
+	defer srv.Close()
+
+	if _, err := newTestFetcher(0).Fetch(context.Background(), srv.URL); !errors.Is(err, ErrUnsupportedContent) {
+		t.Fatalf("Fetch error = %v, want %v", err, ErrUnsupportedContent)
+	}
+}
+
+func TestFetchOpenGraphAndOEmbedFallback(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/og", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<html><head>
+			<title>Fallback title</title>
+			<meta property="og:title" content="OG title">
+			<meta property="og:description" content="OG description">
+			<meta property="og:site_name" content="Example">
+			<meta property="og:image" content="/img.png">
+			<meta property="og:type" content="article">
+			<link rel="alternate" type="application/json+oembed" href="/oembed">
+		</head><body></body></html>`)
+	})
+	mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head>
+			<title>Plain title</title>
+			<link rel="alternate" type="application/json+oembed" href="/oembed">
+		</head></html>`)
+	})
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"type":"video","title":"oEmbed title","author_name":"Author","provider_name":"Provider","thumbnail_url":"https://cdn.example.com/thumb.png"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	f := newTestFetcher(0)
+
+	og, err := f.Fetch(context.Background(), srv.URL+"/og")
+	if err != nil {
+		t.Fatalf("Fetch /og: %v", err)
+	}
+	// OpenGraph values win; oEmbed only fills what the page left empty.
+	want := map[string][2]string{
+		"Title":       {og.Title, "OG title"},
+		"Description": {og.Description, "OG description"},
+		"SiteName":    {og.SiteName, "Example"},
+		"ImageURL":    {og.ImageURL, srv.URL + "/img.png"},
+		"Type":        {og.Type, "article"},
+		"AuthorName":  {og.AuthorName, "Author"},
+	}
+	for field, v := range want {
+		if v[0] != v[1] {
+			t.Errorf("/og %s = %q, want %q", field, v[0], v[1])
+		}
+	}
+
+	plain, err := f.Fetch(context.Background(), srv.URL+"/plain")
+	if err != nil {
+		t.Fatalf("Fetch /plain: %v", err)
+	}
+	want = map[string][2]string{
+		"Title":      {plain.Title, "Plain title"},
+		"SiteName":   {plain.SiteName, "Provider"},
+		"ImageURL":   {plain.ImageURL, "https://cdn.example.com/thumb.png"},
+		"Type":       {plain.Type, "video"},
+		"AuthorName": {plain.AuthorName, "Author"},
+	}
+	for field, v := range want {
+		if v[0] != v[1] {
+			t.Errorf("/plain %s = %q, want %q", field, v[0], v[1])
+		}
+	}
+}
+
+func TestFetchImage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte{0x89, 'P', 'N', 'G'})
+	}))
+	defer srv.Close()
+
+	preview, err := newTestFetcher(0).Fetch(context.Background(), srv.URL+"/pic.png")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if preview.Type != "image" || preview.ImageURL != srv.URL+"/pic.png" {
+		t.Errorf("preview = {Type: %q, ImageURL: %q}, want image at %s/pic.png", preview.Type, preview.ImageURL, srv.URL)
+	}
+}
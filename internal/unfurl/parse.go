@@ -0,0 +1,133 @@
+package unfurl
+
+import (
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/quckapp/channel-service/internal/models"
+	"golang.org/x/net/html"
+)
+
+const maxFieldRunes = 500
+
+// pageMeta is what parseHTML pulls out of a document head. OpenGraph
+// values win over the <title> and description fallbacks.
+type pageMeta struct {
+	og          map[string]string
+	title       string
+	description string
+	oembedURL   string
+}
+
+func (m *pageMeta) apply(p *models.LinkPreview) {
+	p.Title = truncate(firstNonEmpty(m.og["og:title"], m.og["twitter:title"], m.title))
+	p.Description = truncate(firstNonEmpty(m.og["og:description"], m.og["twitter:description"], m.description))
+	p.SiteName = truncate(m.og["og:site_name"])
+	p.ImageURL = firstNonEmpty(m.og["og:image"], m.og["og:image:url"], m.og["twitter:image"])
+	p.Type = m.og["og:type"]
+}
+
+// parseHTML tokenizes the document until the end of <head> (or the start
+// of <body>), resolving image and oEmbed URLs against base.
+func parseHTML(r io.Reader, base *url.URL) *pageMeta {
+	meta := &pageMeta{og: make(map[string]string)}
+	z := html.NewTokenizer(r)
+	inTitle := false
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			meta.resolve(base)
+			return meta
+		case html.TextToken:
+			if inTitle && meta.title == "" {
+				meta.title = strings.TrimSpace(string(z.Text()))
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				meta.resolve(base)
+				return meta
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			attrs := map[string]string{}
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				attrs[string(key)] = string(val)
+			}
+			switch string(name) {
+			case "title":
+				inTitle = true
+			case "body":
+				meta.resolve(base)
+				return meta
+			case "meta":
+				meta.addMeta(attrs)
+			case "link":
+				if strings.EqualFold(attrs["rel"], "alternate") && strings.EqualFold(attrs["type"], "application/json+oembed") && meta.oembedURL == "" {
+					meta.oembedURL = attrs["href"]
+				}
+			}
+		}
+	}
+}
+
+func (m *pageMeta) addMeta(attrs map[string]string) {
+	content := strings.TrimSpace(attrs["content"])
+	if content == "" {
+		return
+	}
+	key := strings.ToLower(firstNonEmpty(attrs["property"], attrs["name"]))
+	switch {
+	case key == "description":
+		m.description = content
+	case strings.HasPrefix(key, "og:") || strings.HasPrefix(key, "twitter:"):
+		if _, ok := m.og[key]; !ok {
+			m.og[key] = content
+		}
+	}
+}
+
+func (m *pageMeta) resolve(base *url.URL) {
+	for _, key := range []string{"og:image", "og:image:url", "twitter:image"} {
+		if v, ok := m.og[key]; ok {
+			m.og[key] = resolveHTTP(base, v)
+		}
+	}
+	m.oembedURL = resolveHTTP(base, m.oembedURL)
+}
+
+// resolveHTTP makes ref absolute against base and drops anything that is
+// not http(s), such as javascript: or data: URLs.
+func resolveHTTP(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := base.Parse(strings.TrimSpace(ref))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func truncate(s string) string {
+	if runes := []rune(s); len(runes) > maxFieldRunes {
+		return string(runes[:maxFieldRunes])
+	}
+	return s
+}
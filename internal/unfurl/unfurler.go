@@ -0,0 +1,108 @@
+package unfurl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/quckapp/channel-service/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrUnavailable = errors.New("link preview unavailable")
+
+const (
+	DefaultCacheTTL = 24 * time.Hour
+	// failureTTL keeps a failing URL from being refetched on every request.
+	failureTTL = 10 * time.Minute
+	// failureMarker is cached in place of a preview when a fetch fails.
+	failureMarker = "-"
+)
+
+// Unfurler fronts a Fetcher with a Redis cache. A nil redis client
+// disables caching.
+type Unfurler struct {
+	fetcher Fetcher
+	redis   *redis.Client
+	ttl     time.Duration
+}
+
+func NewUnfurler(fetcher Fetcher, redisClient *redis.Client, ttl time.Duration) *Unfurler {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &Unfurler{fetcher: fetcher, redis: redisClient, ttl: ttl}
+}
+
+func cacheKey(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return "unfurl:" + hex.EncodeToString(sum[:])
+}
+
+func (u *Unfurler) CacheEnabled() bool {
+	return u.redis != nil
+}
+
+// Unfurl returns the cached preview for rawURL, fetching and caching it on
+// a miss. Recently failed URLs return ErrUnavailable without a refetch.
+func (u *Unfurler) Unfurl(ctx context.Context, rawURL string) (*models.LinkPreview, error) {
+	if u.redis != nil {
+		if data, err := u.redis.Get(ctx, cacheKey(rawURL)).Result(); err == nil {
+			if data == failureMarker {
+				return nil, ErrUnavailable
+			}
+			var preview models.LinkPreview
+			if json.Unmarshal([]byte(data), &preview) == nil {
+				return &preview, nil
+			}
+		}
+	}
+
+	preview, err := u.fetcher.Fetch(ctx, rawURL)
+	if err != nil {
+		if ctx.Err() == nil && u.redis != nil {
+			u.redis.Set(ctx, cacheKey(rawURL), failureMarker, failureTTL)
+		}
+		return nil, err
+	}
+
+	if u.redis != nil {
+		if data, err := json.Marshal(preview); err == nil {
+			u.redis.Set(ctx, cacheKey(rawURL), data, u.ttl)
+		}
+	}
+	return preview, nil
+}
+
+// Cached returns the previews already cached for urls, keyed by URL,
+// without fetching anything.
+func (u *Unfurler) Cached(ctx context.Context, urls []string) map[string]*models.LinkPreview {
+	previews := make(map[string]*models.LinkPreview)
+	if u.redis == nil || len(urls) == 0 {
+		return previews
+	}
+
+	keys := make([]string, len(urls))
+	for i, rawURL := range urls {
+		keys[i] = cacheKey(rawURL)
+	}
+	values, err := u.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return previews
+	}
+
+	for i, v := range values {
+		data, ok := v.(string)
+		if !ok || data == failureMarker {
+			continue
+		}
+		var preview models.LinkPreview
+		if json.Unmarshal([]byte(data), &preview) == nil {
+			previews[urls[i]] = &preview
+		}
+	}
+	return previews
+}